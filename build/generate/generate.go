@@ -7,13 +7,17 @@ import (
 )
 
 func main() {
+	if err := generateTypes(); err != nil {
+		log.Fatal(err)
+	}
+
 	conf := &embed.Config{
 		ModTime:    "0",
 		OutputFile: "pkg/js/static.go",
 		Package:    "js",
 		Prefix:     "pkg/js",
 		Private:    true,
-		Files:      []string{`pkg/js/helpers.js`},
+		Files:      []string{`pkg/js/helpers.js`, typesFile},
 	}
 	embed.Run(conf)
 
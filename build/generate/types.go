@@ -0,0 +1,309 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/providers"
+	"gopkg.in/yaml.v2"
+)
+
+// docsURL is where the documentation of the functions is published.
+const docsURL = "https://stackexchange.github.io/dnscontrol"
+
+// typesFile is embedded in the binary and written by `dnscontrol write-types`.
+const typesFile = "pkg/js/types-dnscontrol.d.ts"
+
+// typesHeader declares the types that the declarations below refer to.
+const typesHeader = `// Type declarations for dnsconfig.js, for editor completion and type checking.
+// Generated by build/generate/types.go from docs/_functions and pkg/js/helpers.js. DO NOT HAND EDIT!
+//
+// Reference this file at the top of dnsconfig.js:
+//   /// <reference path="types-dnscontrol.d.ts" />
+
+/** Metadata for a domain or record, such as CF_PROXY_ON. */
+declare interface Metadata {
+    [key: string]: string;
+}
+
+/** A duration in seconds, or a string such as "5m", "1h" or "1d". */
+declare type Duration = number | string;
+
+/** An argument of D(), D_EXTEND() and DEFAULTS(): a record, a domain setting, metadata, or a list of them. */
+declare type DomainModifier = ((domain: any) => void) | Metadata | DomainModifier[];
+
+/** An argument of a record function after its fixed parameters, such as TTL(300). */
+declare type RecordModifier = ((record: any) => void) | Metadata;
+
+/** The values given with --var and --var-file. */
+declare const CLI_VARS: { readonly [key: string]: string };
+`
+
+// funcDoc is the front matter of a file in docs/_functions.
+type funcDoc struct {
+	Name           string            `yaml:"name"`
+	Parameters     []string          `yaml:"parameters"`
+	ParameterTypes map[string]string `yaml:"parameter_types"`
+	Return         string            `yaml:"return"`
+
+	kind        string   // global, domain or record
+	description []string // the first paragraph of the documentation
+}
+
+// declaration is one declared name in the types file.
+type declaration struct {
+	name        string
+	description []string
+	code        string
+	documented  bool // whether docs/_functions has a page for it
+}
+
+func generateTypes() error {
+	decls := map[string]*declaration{}
+
+	// Functions documented in docs/_functions.
+	for _, kind := range []string{"global", "domain", "record"} {
+		files, err := filepath.Glob(filepath.Join("docs/_functions", kind, "*.md"))
+		if err != nil {
+			return err
+		}
+		for _, file := range files {
+			doc, err := readFuncDoc(file, kind)
+			if err != nil {
+				return err
+			}
+			decls[doc.Name] = &declaration{name: doc.Name, description: doc.description, code: doc.declare(), documented: true}
+		}
+	}
+
+	// Undocumented functions and constants in helpers.js.
+	helpers, err := readHelpers("pkg/js/helpers.js")
+	if err != nil {
+		return err
+	}
+	for _, d := range helpers {
+		if decls[d.name] == nil {
+			decls[d.name] = d
+		}
+	}
+
+	// Record types registered by providers.
+	for _, name := range providers.CustomRecordTypes() {
+		rt := providers.GetCustomRecordType(name)
+		d := decls[name]
+		if d == nil {
+			d = &declaration{
+				name: name,
+				code: fmt.Sprintf("declare function %s(name: string, target: string, ...modifiers: RecordModifier[]): DomainModifier;", name),
+			}
+			decls[name] = d
+		}
+		if len(d.description) > 0 {
+			d.description = append(d.description, "")
+		}
+		d.description = append(d.description, fmt.Sprintf("Only supported by %s.", rt.Provider))
+	}
+
+	names := []string{}
+	for name := range decls {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	buf := &bytes.Buffer{}
+	buf.WriteString(typesHeader)
+	for _, name := range names {
+		d := decls[name]
+		buf.WriteString("\n/**\n")
+		for _, line := range d.description {
+			writeDocLine(buf, line)
+		}
+		if d.documented {
+			if len(d.description) > 0 {
+				writeDocLine(buf, "")
+			}
+			writeDocLine(buf, fmt.Sprintf("@see %s/js#%s", docsURL, name))
+		}
+		buf.WriteString(" */\n")
+		buf.WriteString(d.code + "\n")
+	}
+	return ioutil.WriteFile(typesFile, buf.Bytes(), 0644)
+}
+
+var localLink = regexp.MustCompile(`\]\(#(\w+)\)`)
+
+// writeDocLine writes a line of a doc comment, with the links of the
+// documentation made absolute.
+func writeDocLine(buf *bytes.Buffer, line string) {
+	line = strings.Replace(line, "{{site.github.url}}", docsURL, -1)
+	line = localLink.ReplaceAllString(line, "]("+docsURL+"/js#$1)")
+	line = strings.TrimSpace(strings.Replace(line, "*/", "*\\/", -1))
+	if line == "" {
+		buf.WriteString(" *\n")
+		return
+	}
+	fmt.Fprintf(buf, " * %s\n", line)
+}
+
+// readFuncDoc reads the front matter and the first paragraph of a function's documentation.
+func readFuncDoc(file, kind string) (*funcDoc, error) {
+	content, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	parts := strings.SplitN(strings.Replace(string(content), "\r\n", "\n", -1), "---\n", 3)
+	if len(parts) != 3 || parts[0] != "" {
+		return nil, fmt.Errorf("%s: no front matter", file)
+	}
+	doc := &funcDoc{kind: kind}
+	if err := yaml.Unmarshal([]byte(parts[1]), doc); err != nil {
+		return nil, fmt.Errorf("%s: %s", file, err)
+	}
+	for _, line := range strings.Split(strings.TrimSpace(parts[2]), "\n") {
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "{%") {
+			break
+		}
+		doc.description = append(doc.description, line)
+	}
+	for p := range doc.ParameterTypes {
+		if !doc.hasParameter(strings.TrimSuffix(p, "?")) {
+			return nil, fmt.Errorf("%s: parameter_types has unknown parameter %s", file, p)
+		}
+	}
+	return doc, nil
+}
+
+func (doc *funcDoc) hasParameter(name string) bool {
+	for _, p := range doc.Parameters {
+		if p == name {
+			return true
+		}
+	}
+	return false
+}
+
+// declare returns the TypeScript declaration of the function. Functions
+// without parameters, such as NO_PURGE, are used without calling them.
+func (doc *funcDoc) declare() string {
+	modifier := "DomainModifier"
+	if doc.kind == "record" {
+		modifier = "RecordModifier"
+	}
+	if len(doc.Parameters) == 0 {
+		return fmt.Sprintf("declare const %s: %s;", doc.Name, modifier)
+	}
+	ret := doc.Return
+	if ret == "" {
+		ret = modifier
+		if doc.kind == "global" {
+			ret = "void"
+		}
+	}
+	params := []string{}
+	for _, p := range doc.Parameters {
+		typ, optional := doc.ParameterTypes[p], false
+		if typ == "" {
+			if typ = doc.ParameterTypes[p+"?"]; typ != "" {
+				optional = true
+			}
+		}
+		if typ == "" {
+			typ = "any"
+		}
+		if strings.HasSuffix(p, "...") {
+			if !strings.HasSuffix(typ, "[]") {
+				typ = "any[]"
+			}
+			params = append(params, "..."+identifier(strings.TrimSuffix(p, "..."))+": "+typ)
+			continue
+		}
+		if optional {
+			params = append(params, identifier(p)+"?: "+typ)
+		} else {
+			params = append(params, identifier(p)+": "+typ)
+		}
+	}
+	return fmt.Sprintf("declare function %s(%s): %s;", doc.Name, strings.Join(params, ", "), ret)
+}
+
+var (
+	nonIdentifier = regexp.MustCompile(`[^A-Za-z0-9_]+`)
+	reserved      = map[string]bool{"function": true, "default": true, "class": true, "new": true, "delete": true, "in": true}
+)
+
+// identifier turns a documented parameter name like "transform table" into a TypeScript identifier.
+func identifier(name string) string {
+	name = nonIdentifier.ReplaceAllString(name, "_")
+	if reserved[name] {
+		name += "_"
+	}
+	return name
+}
+
+var (
+	helperFunc  = regexp.MustCompile(`^function ([A-Z]\w*)\((.*)\)`)
+	helperVar   = regexp.MustCompile(`^var ([A-Z]\w*) = (.*?);?\s*(?://\s*(.*))?$`)
+	lineComment = regexp.MustCompile(`^//\s?(.*)$`)
+)
+
+// readHelpers returns the declarations of the functions and constants in
+// helpers.js whose names start with a capital letter. The comment lines
+// above a function are its description. The description of a constant is the
+// comment after it, or else the line above it.
+func readHelpers(file string) ([]*declaration, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var decls []*declaration
+	var comment []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if m := lineComment.FindStringSubmatch(line); m != nil {
+			comment = append(comment, m[1])
+			continue
+		}
+		if m := helperFunc.FindStringSubmatch(line); m != nil {
+			params := []string{}
+			for _, p := range strings.Split(m[2], ",") {
+				if p = strings.TrimSpace(p); p != "" {
+					params = append(params, p+"?: any")
+				}
+			}
+			decls = append(decls, &declaration{
+				name:        m[1],
+				description: comment,
+				code:        fmt.Sprintf("declare function %s(%s): any;", m[1], strings.Join(params, ", ")),
+			})
+		} else if m := helperVar.FindStringSubmatch(line); m != nil {
+			var desc []string
+			if m[3] != "" {
+				desc = []string{m[3]}
+			} else if len(comment) > 0 {
+				desc = comment[len(comment)-1:]
+			}
+			var code string
+			switch {
+			case strings.HasPrefix(m[2], "recordBuilder("):
+				code = fmt.Sprintf("declare function %s(name: string, target: string, ...modifiers: RecordModifier[]): DomainModifier;", m[1])
+			case strings.HasPrefix(m[2], "{"):
+				code = fmt.Sprintf("declare const %s: Metadata;", m[1])
+			default:
+				code = fmt.Sprintf("declare const %s: RecordModifier;", m[1])
+			}
+			decls = append(decls, &declaration{name: m[1], description: desc, code: code})
+		}
+		comment = nil
+	}
+	return decls, scanner.Err()
+}
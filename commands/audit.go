@@ -0,0 +1,138 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/models"
+	"github.com/StackExchange/dnscontrol/pkg/audit"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+)
+
+var _ = cmd(catUtils, func() *cli.Command {
+	var args AuditArgs
+	return &cli.Command{
+		Name:  "audit",
+		Usage: "search the audit log written by push -audit-log",
+		Description: "Only the BIND, DIGITALOCEAN and LINODE providers report the records a correction\n" +
+			"   replaced and created. Entries from every other provider show them as unknown\n" +
+			"   (records_unknown in the log); the correction message is still recorded.",
+		Action: func(ctx *cli.Context) error {
+			return exit(Audit(args))
+		},
+		Flags: args.flags(),
+	}
+}())
+
+// AuditArgs contains all data/flags needed to run audit, independently of CLI.
+type AuditArgs struct {
+	LogFile string
+	Domain  string
+	Since   string
+	Until   string
+	JSON    bool
+}
+
+func (args *AuditArgs) flags() []cli.Flag {
+	return []cli.Flag{
+		cli.StringFlag{
+			Name:        "log",
+			Destination: &args.LogFile,
+			EnvVar:      "DNSCONTROL_AUDIT_LOG",
+			Usage:       "Audit log to search",
+		},
+		cli.StringFlag{
+			Name:        "domain",
+			Destination: &args.Domain,
+			Usage:       "Only show entries for this domain",
+		},
+		cli.StringFlag{
+			Name:        "since",
+			Destination: &args.Since,
+			Usage:       "Only show entries at or after this time (2006-01-02 or RFC3339)",
+		},
+		cli.StringFlag{
+			Name:        "until",
+			Destination: &args.Until,
+			Usage:       "Only show entries before this time (2006-01-02 or RFC3339)",
+		},
+		cli.BoolFlag{
+			Name:        "json",
+			Destination: &args.JSON,
+			Usage:       "Print matching entries as JSON lines",
+		},
+	}
+}
+
+func parseAuditTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	t, err := time.ParseInLocation("2006-01-02", s, time.Local)
+	if err != nil {
+		return t, errors.Errorf("invalid time %q: use 2006-01-02 or RFC3339", s)
+	}
+	return t, nil
+}
+
+// Audit implements the audit subcommand.
+func Audit(args AuditArgs) error {
+	if args.LogFile == "" {
+		return errors.Errorf("No audit log specified. Use -log or DNSCONTROL_AUDIT_LOG")
+	}
+	var err error
+	filter := audit.Filter{Domain: args.Domain}
+	if filter.Since, err = parseAuditTime(args.Since); err != nil {
+		return err
+	}
+	if filter.Until, err = parseAuditTime(args.Until); err != nil {
+		return err
+	}
+	f, err := os.Open(args.LogFile)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	entries, err := audit.Search(f, filter)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(os.Stdout)
+	for _, e := range entries {
+		if args.JSON {
+			if err := enc.Encode(e); err != nil {
+				return err
+			}
+			continue
+		}
+		fmt.Printf("%s %s@%s %s[%s] %s", e.Time.Local().Format(time.RFC3339), e.User, e.Host, e.Domain, e.Provider, e.Result)
+		if e.Error != "" {
+			fmt.Printf(" (%s)", e.Error)
+		}
+		fmt.Printf("\n    %s\n", e.Correction)
+		fmt.Printf("    before: %s\n    after:  %s\n", auditRecords(e, e.Before), auditRecords(e, e.After))
+	}
+	return nil
+}
+
+// auditRecords describes the records before or after a correction.
+func auditRecords(e *audit.Entry, records []*models.RecordConfig) string {
+	if e.RecordsUnknown {
+		return "unknown"
+	}
+	if len(records) == 0 {
+		return "none"
+	}
+	s := make([]string, len(records))
+	for i, r := range records {
+		s[i] = fmt.Sprintf("%s %s %s", r.Type, r.GetLabel(), r.GetTargetCombined())
+	}
+	return strings.Join(s, ", ")
+}
@@ -0,0 +1,157 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/models"
+	"github.com/StackExchange/dnscontrol/providers"
+	"github.com/StackExchange/dnscontrol/providers/config"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+)
+
+var _ = cmd(catUtils, func() *cli.Command {
+	var args CheckCredsArgs
+	return &cli.Command{
+		Name:  "check-creds",
+		Usage: "check that the credentials of every provider work, without changing anything",
+		Action: func(ctx *cli.Context) error {
+			return exit(CheckCreds(args))
+		},
+		Flags: args.flags(),
+	}
+}())
+
+// CheckCredsArgs contains all data/flags needed to run check-creds, independently of CLI.
+type CheckCredsArgs struct {
+	GetDNSConfigArgs
+	GetCredentialsArgs
+	Providers string
+}
+
+func (args *CheckCredsArgs) flags() []cli.Flag {
+	flags := args.GetDNSConfigArgs.flags()
+	flags = append(flags, args.GetCredentialsArgs.flags()...)
+	flags = append(flags, cli.StringFlag{
+		Name:        "providers",
+		Destination: &args.Providers,
+		Usage:       `Providers to check (comma separated list). Default is all providers in the configuration.`,
+	})
+	return flags
+}
+
+// checkedProvider is a registrar or DNS provider declared in the configuration.
+type checkedProvider struct {
+	name, typ string
+	registrar bool
+	domains   []string // domains in the configuration that use the provider, by UniqueName
+}
+
+// CheckCreds implements the check-creds subcommand. Each provider is created as InitializeProviders
+// would, and probed with providers.CredentialsChecker if it implements it. A provider that cannot be
+// created does not prevent the others from being checked.
+func CheckCreds(args CheckCredsArgs) error {
+	cfg, err := GetDNSConfig(args.GetDNSConfigArgs)
+	if err != nil {
+		return err
+	}
+	configs, err := config.ReadProviderConfigs(args.CredsFile, args.Profile)
+	if err != nil {
+		return err
+	}
+	only := map[string]bool{}
+	for _, p := range strings.Split(args.Providers, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			only[p] = true
+		}
+	}
+
+	var checks []*checkedProvider
+	byKey := map[string]*checkedProvider{}
+	add := func(name, typ string, registrar bool, domain string) {
+		if len(only) > 0 && !only[name] {
+			return
+		}
+		key := fmt.Sprintf("%s/%v", name, registrar)
+		if byKey[key] == nil {
+			byKey[key] = &checkedProvider{name: name, typ: typ, registrar: registrar}
+			checks = append(checks, byKey[key])
+		}
+		byKey[key].domains = append(byKey[key].domains, domain)
+	}
+	for _, d := range cfg.Domains {
+		add(d.RegistrarName, d.RegistrarInstance.ProviderType, true, d.UniqueName())
+		for _, p := range d.DNSProviderInstances {
+			add(p.Name, p.ProviderType, false, d.UniqueName())
+		}
+	}
+	sort.SliceStable(checks, func(i, j int) bool { return checks[i].name < checks[j].name })
+
+	failed := 0
+	for _, c := range checks {
+		kind := "DNS provider"
+		if c.registrar {
+			kind = "registrar"
+		}
+		fmt.Printf("%s (%s %s)\n", c.name, c.typ, kind)
+		if err := checkProvider(c, configs, cfg); err != nil {
+			fmt.Printf("  auth:  FAILED: %s\n", err)
+			failed++
+		}
+	}
+	if failed > 0 {
+		return errors.Errorf("Credentials check failed for %d of %d providers", failed, len(checks))
+	}
+	return nil
+}
+
+func checkProvider(c *checkedProvider, configs map[string]map[string]string, cfg *models.DNSConfig) error {
+	if err := config.ResolveReferences(configs, c.name); err != nil {
+		return err
+	}
+	var driver interface{}
+	var err error
+	if c.registrar {
+		driver, err = providers.CreateRegistrar(c.typ, configs[c.name])
+	} else {
+		driver, err = providers.CreateDNSProvider(c.typ, configs[c.name], cfg.DNSProvidersByName[c.name].Metadata)
+	}
+	if err != nil {
+		return err
+	}
+	checker, ok := driver.(providers.CredentialsChecker)
+	if !ok {
+		fmt.Printf("  auth:  ok (the provider accepted the credentials; it has no further checks)\n")
+		return nil
+	}
+	report, err := checker.CheckCredentials()
+	if err != nil {
+		return err
+	}
+	if report.Account != "" {
+		fmt.Printf("  auth:  ok (%s)\n", report.Account)
+	} else {
+		fmt.Printf("  auth:  ok\n")
+	}
+	if report.Zones != nil {
+		fmt.Printf("  zones: %d visible\n", len(report.Zones))
+		visible := map[string]bool{}
+		for _, z := range report.Zones {
+			visible[strings.ToLower(z)] = true
+			fmt.Printf("         %s\n", z)
+		}
+		if !c.registrar {
+			for _, d := range c.domains {
+				// A view is visible as its zone name, or under its unique name (as BIND zonefiles are).
+				zone := strings.SplitN(d, "!", 2)[0]
+				if !visible[strings.ToLower(d)] && !visible[strings.ToLower(zone)] {
+					fmt.Printf("  WARNING: %s is in the configuration but not visible (create-domains may be needed)\n", d)
+				}
+			}
+		}
+	}
+	fmt.Printf("  write: %s\n", report.Write)
+	return nil
+}
@@ -3,12 +3,16 @@ package commands
 import (
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/StackExchange/dnscontrol/models"
+	"github.com/StackExchange/dnscontrol/pkg/js"
 	"github.com/StackExchange/dnscontrol/pkg/printer"
+	"github.com/gobwas/glob"
 	"github.com/pkg/errors"
 	"github.com/urfave/cli"
 )
@@ -125,7 +129,7 @@ func preloadProviders(cfg *models.DNSConfig, err error) (*models.DNSConfig, erro
 	for _, d := range cfg.Domains {
 		reg, ok := cfg.RegistrarsByName[d.RegistrarName]
 		if !ok {
-			return nil, errors.Errorf("Registrar named %s expected for %s, but never registered", d.RegistrarName, d.Name)
+			return nil, errors.Errorf("Registrar named %s expected for %s, but never registered", d.RegistrarName, d.UniqueName())
 		}
 		d.RegistrarInstance = &models.RegistrarInstance{
 			ProviderBase: models.ProviderBase{
@@ -156,13 +160,17 @@ func preloadProviders(cfg *models.DNSConfig, err error) (*models.DNSConfig, erro
 
 // ExecuteDSLArgs are used anytime we need to read and execute dnscontrol DSL
 type ExecuteDSLArgs struct {
-	JSFile   string
-	JSONFile string
-	DevMode  bool
+	JSLimitsArgs
+	JSFile     string
+	JSONFile   string
+	DevMode    bool
+	Vars       cli.StringSlice
+	VarFile    string
+	AllowedEnv cli.StringSlice
 }
 
 func (args *ExecuteDSLArgs) flags() []cli.Flag {
-	return []cli.Flag{
+	return append(args.JSLimitsArgs.flags(),
 		cli.StringFlag{
 			Name:        "config",
 			Value:       "dnsconfig.js",
@@ -181,6 +189,85 @@ func (args *ExecuteDSLArgs) flags() []cli.Flag {
 			Destination: &args.DevMode,
 			Usage:       "Use helpers.js from disk instead of embedded copy",
 		},
+		cli.StringSliceFlag{
+			Name:  "var",
+			Value: &args.Vars,
+			Usage: "Set CLI_VARS.key to value in dnsconfig.js (key=value, repeatable)",
+		},
+		cli.StringFlag{
+			Name:        "var-file",
+			Destination: &args.VarFile,
+			Usage:       "Read CLI_VARS from this JSON file. -var overrides its values",
+		},
+		cli.StringSliceFlag{
+			Name:  "allow-env",
+			Value: &args.AllowedEnv,
+			Usage: "Allow dnsconfig.js to read this environment variable with ENV() (repeatable, or comma separated)",
+		},
+	)
+}
+
+// variables returns the CLI_VARS and the ENV() allowlist given on the command line.
+func (args *ExecuteDSLArgs) variables() (js.Variables, error) {
+	vars := js.Variables{CLI: map[string]string{}}
+	if args.VarFile != "" {
+		data, err := ioutil.ReadFile(args.VarFile)
+		if err != nil {
+			return vars, errors.Wrap(err, "reading -var-file")
+		}
+		if err := json.Unmarshal(data, &vars.CLI); err != nil {
+			return vars, errors.Wrapf(err, "%s must be a JSON object of strings", args.VarFile)
+		}
+	}
+	for _, v := range args.Vars {
+		kv := strings.SplitN(v, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return vars, errors.Errorf("-var %q is not in the form key=value", v)
+		}
+		vars.CLI[kv[0]] = kv[1]
+	}
+	for _, names := range args.AllowedEnv {
+		for _, name := range strings.Split(names, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				vars.AllowedEnv = append(vars.AllowedEnv, name)
+			}
+		}
+	}
+	return vars, nil
+}
+
+// JSLimitsArgs limit what dnsconfig.js may do while it runs.
+type JSLimitsArgs struct {
+	JSTimeout   time.Duration
+	JSMaxMemory int
+	Sandbox     bool
+}
+
+func (args *JSLimitsArgs) flags() []cli.Flag {
+	return []cli.Flag{
+		cli.DurationFlag{
+			Name:        "js-timeout",
+			Destination: &args.JSTimeout,
+			Usage:       "Stop dnsconfig.js if it runs longer than this (for example 30s). 0 means no limit",
+		},
+		cli.IntFlag{
+			Name:        "js-max-memory",
+			Destination: &args.JSMaxMemory,
+			Usage:       "Stop dnsconfig.js if it uses more than this many MiB of memory. 0 means no limit",
+		},
+		cli.BoolFlag{
+			Name:        "sandbox",
+			Destination: &args.Sandbox,
+			Usage:       "Only allow require() of files in the directory of the config file",
+		},
+	}
+}
+
+func (args *JSLimitsArgs) limits() js.Limits {
+	return js.Limits{
+		Timeout:   args.JSTimeout,
+		MaxMemory: uint64(args.JSMaxMemory) << 20,
+		Sandbox:   args.Sandbox,
 	}
 }
 
@@ -208,6 +295,7 @@ func (args *PrintJSONArgs) flags() []cli.Flag {
 // GetCredentialsArgs encapsulates the flags/args for sub-commands that use the creds.json file.
 type GetCredentialsArgs struct {
 	CredsFile string
+	Profile   string
 }
 
 func (args *GetCredentialsArgs) flags() []cli.Flag {
@@ -215,9 +303,15 @@ func (args *GetCredentialsArgs) flags() []cli.Flag {
 		cli.StringFlag{
 			Name:        "creds",
 			Destination: &args.CredsFile,
-			Usage:       "Provider credentials JSON file",
+			Usage:       "Provider credentials JSON file, or several separated by commas (later files override earlier ones)",
 			Value:       "creds.json",
 		},
+		cli.StringFlag{
+			Name:        "profile",
+			Destination: &args.Profile,
+			EnvVar:      "DNSCONTROL_PROFILE",
+			Usage:       "Use the credentials of this profile from the creds file",
+		},
 	}
 }
 
@@ -225,20 +319,33 @@ func (args *GetCredentialsArgs) flags() []cli.Flag {
 type FilterArgs struct {
 	Providers string
 	Domains   string
+	Tags      string
 }
 
 func (args *FilterArgs) flags() []cli.Flag {
-	return []cli.Flag{
+	return append([]cli.Flag{
 		cli.StringFlag{
 			Name:        "providers",
 			Destination: &args.Providers,
 			Usage:       `Providers to enable (comma separated list); default is all. Can exclude individual providers from default by adding '"_exclude_from_defaults": "true"' to the credentials file for a provider`,
 			Value:       "",
 		},
+	}, args.domainFlags()...)
+}
+
+// domainFlags are the flags that select domains, for sub-commands that do not filter by provider.
+func (args *FilterArgs) domainFlags() []cli.Flag {
+	return []cli.Flag{
 		cli.StringFlag{
 			Name:        "domains",
 			Destination: &args.Domains,
-			Usage:       `Comma separated list of domain names to include`,
+			Usage:       `Comma separated list of domain names to include. Names may be globs: * matches one label, ** any number of labels. Use name!view to select one view`,
+			Value:       "",
+		},
+		cli.StringFlag{
+			Name:        "tags",
+			Destination: &args.Tags,
+			Usage:       `Comma separated list of tags (see TAGS) to include. Prefix a tag with ! to exclude domains that have it`,
 			Value:       "",
 		},
 	}
@@ -264,14 +371,72 @@ func (args *FilterArgs) shouldRunProvider(name string, dc *models.DomainConfig)
 	return false
 }
 
-func (args *FilterArgs) shouldRunDomain(d string) bool {
+func (args *FilterArgs) shouldRunDomain(dc *models.DomainConfig) bool {
+	return args.matchDomainName(dc) && args.matchTags(dc.Tags)
+}
+
+// matchDomainName reports whether dc is selected by -domains.
+// "example.com" selects all views of example.com, "example.com!view" only that view.
+func (args *FilterArgs) matchDomainName(dc *models.DomainConfig) bool {
 	if args.Domains == "" {
 		return true
 	}
+	name, view := strings.ToLower(dc.Name), strings.ToLower(dc.View)
 	for _, dom := range strings.Split(args.Domains, ",") {
-		if dom == d {
+		dom = strings.ToLower(strings.TrimSpace(dom))
+		if i := strings.Index(dom, "!"); i != -1 {
+			if !matchPattern(dom[i+1:], view, 0) {
+				continue
+			}
+			dom = dom[:i]
+		}
+		if matchPattern(dom, name, '.') {
 			return true
 		}
 	}
 	return false
 }
+
+// matchPattern reports whether s is pattern, or matches it as a glob.
+func matchPattern(pattern, s string, separator rune) bool {
+	if pattern == s {
+		return true
+	}
+	var g glob.Glob
+	var err error
+	if separator == 0 {
+		g, err = glob.Compile(pattern)
+	} else {
+		g, err = glob.Compile(pattern, separator)
+	}
+	return err == nil && g.Match(s)
+}
+
+// matchTags reports whether tags has any of the wanted tags (if any are wanted), and none of the excluded ones.
+func (args *FilterArgs) matchTags(tags []string) bool {
+	if args.Tags == "" {
+		return true
+	}
+	has := map[string]bool{}
+	for _, t := range tags {
+		has[t] = true
+	}
+	wanted, found := false, false
+	for _, t := range strings.Split(args.Tags, ",") {
+		t = strings.TrimSpace(t)
+		if t == "" {
+			continue
+		}
+		if strings.HasPrefix(t, "!") {
+			if has[t[1:]] {
+				return false
+			}
+			continue
+		}
+		wanted = true
+		if has[t] {
+			found = true
+		}
+	}
+	return found || !wanted
+}
@@ -3,6 +3,7 @@ package commands
 import (
 	"fmt"
 
+	"github.com/StackExchange/dnscontrol/models"
 	"github.com/StackExchange/dnscontrol/providers"
 	"github.com/urfave/cli"
 )
@@ -23,11 +24,13 @@ var _ = cmd(catUtils, func() *cli.Command {
 type CreateDomainsArgs struct {
 	GetDNSConfigArgs
 	GetCredentialsArgs
+	FilterArgs
 }
 
 func (args *CreateDomainsArgs) flags() []cli.Flag {
 	flags := args.GetDNSConfigArgs.flags()
 	flags = append(flags, args.GetCredentialsArgs.flags()...)
+	flags = append(flags, args.FilterArgs.flags()...)
 	return flags
 }
 
@@ -37,15 +40,32 @@ func CreateDomains(args CreateDomainsArgs) error {
 	if err != nil {
 		return err
 	}
-	_, err = InitializeProviders(args.CredsFile, cfg, false)
+	_, err = InitializeProviders(args.GetCredentialsArgs, cfg, false)
 	if err != nil {
 		return err
 	}
+	createDomains(cfg, args.FilterArgs)
+	return nil
+}
+
+// createDomains makes sure that the domains selected by filter exist in their DNS providers.
+// Credentials that are read-only or not allowed to manage a domain are not used for it.
+func createDomains(cfg *models.DNSConfig, filter FilterArgs) {
 	for _, domain := range cfg.Domains {
+		if !filter.shouldRunDomain(domain) {
+			continue
+		}
 		fmt.Println("*** ", domain.Name)
 		for _, provider := range domain.DNSProviderInstances {
+			if !filter.shouldRunProvider(provider.Name, domain) {
+				continue
+			}
 			if creator, ok := provider.Driver.(providers.DomainCreator); ok {
 				fmt.Println("  -", provider.Name)
+				if err := provider.CheckWritable(domain.Name); err != nil {
+					fmt.Printf("Not creating domain: %s\n", err)
+					continue
+				}
 				err := creator.EnsureDomainExists(domain.Name)
 				if err != nil {
 					fmt.Printf("Error creating domain: %s\n", err)
@@ -53,5 +73,4 @@ func CreateDomains(args CreateDomainsArgs) error {
 			}
 		}
 	}
-	return nil
 }
@@ -0,0 +1,40 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/models"
+)
+
+type fakeCreator struct {
+	created []string
+}
+
+func (f *fakeCreator) GetNameservers(string) ([]*models.Nameserver, error) {
+	return nil, nil
+}
+
+func (f *fakeCreator) GetDomainCorrections(dc *models.DomainConfig) ([]*models.Correction, error) {
+	return nil, nil
+}
+
+func (f *fakeCreator) EnsureDomainExists(domain string) error {
+	f.created = append(f.created, domain)
+	return nil
+}
+
+func TestCreateDomainsChecksCredentials(t *testing.T) {
+	creator := &fakeCreator{}
+	instance := func(base models.ProviderBase) *models.DNSProviderInstance {
+		return &models.DNSProviderInstance{ProviderBase: base, Driver: creator}
+	}
+	cfg := &models.DNSConfig{Domains: []*models.DomainConfig{
+		{Name: "ok.com", DNSProviderInstances: []*models.DNSProviderInstance{instance(models.ProviderBase{Name: "dsp"})}},
+		{Name: "readonly.com", DNSProviderInstances: []*models.DNSProviderInstance{instance(models.ProviderBase{Name: "dsp", ReadOnly: true})}},
+		{Name: "other.com", DNSProviderInstances: []*models.DNSProviderInstance{instance(models.ProviderBase{Name: "dsp", AllowedDomains: []string{"ok.com"}})}},
+	}}
+	createDomains(cfg, FilterArgs{Providers: "all"})
+	if len(creator.created) != 1 || creator.created[0] != "ok.com" {
+		t.Errorf("expected only ok.com to be created, got %v", creator.created)
+	}
+}
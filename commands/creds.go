@@ -0,0 +1,225 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/providers/config"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+)
+
+var _ = cmd(catUtils, func() *cli.Command {
+	var enc, dec CredsArgs
+	return &cli.Command{
+		Name:  "creds",
+		Usage: "encrypt or decrypt the credentials file",
+		Subcommands: []cli.Command{
+			{
+				Name:  "encrypt",
+				Usage: "encrypt the credentials file, or only its values, with $DNSCONTROL_CREDS_KEY",
+				Description: "Encrypting the whole file keeps it as written. With -values or -keys, the file is\n" +
+					"   rewritten as indented JSON: comments and formatting are lost, and keys are sorted.",
+				Action: func(ctx *cli.Context) error {
+					return exit(CredsEncrypt(enc))
+				},
+				Flags: enc.flags(true),
+			},
+			{
+				Name:  "decrypt",
+				Usage: "decrypt the credentials file and any encrypted values in it",
+				Description: "If the file has encrypted values, it is rewritten as indented JSON: comments and\n" +
+					"   formatting are lost, and keys are sorted.",
+				Action: func(ctx *cli.Context) error {
+					return exit(CredsDecrypt(dec))
+				},
+				Flags: dec.flags(false),
+			},
+		},
+	}
+}())
+
+// CredsArgs contains all data/flags needed to run creds encrypt and creds decrypt, independently of CLI.
+type CredsArgs struct {
+	CredsFile string
+	Output    string
+	Values    bool
+	Keys      string
+}
+
+func (args *CredsArgs) flags(encrypt bool) []cli.Flag {
+	flags := []cli.Flag{cli.StringFlag{
+		Name:        "creds",
+		Destination: &args.CredsFile,
+		Usage:       "Provider credentials JSON file",
+		Value:       "creds.json",
+	}}
+	flags = append(flags, cli.StringFlag{
+		Name:        "out",
+		Destination: &args.Output,
+		Usage:       "File to write to. Default is to replace the credentials file",
+	})
+	if encrypt {
+		flags = append(flags, cli.BoolFlag{
+			Name:        "values",
+			Destination: &args.Values,
+			Usage:       "Encrypt each value in place instead of the whole file. Keys starting with _ and references such as $VAR are left alone",
+		})
+		flags = append(flags, cli.StringFlag{
+			Name:        "keys",
+			Destination: &args.Keys,
+			Usage:       "Comma separated list of keys whose values to encrypt, for example SecretKey,token. Implies -values",
+		})
+	}
+	return flags
+}
+
+func (args *CredsArgs) output() string {
+	if args.Output != "" {
+		return args.Output
+	}
+	return args.CredsFile
+}
+
+func newCredsCrypter() (*config.Crypter, error) {
+	key, err := config.KeyFromEnv()
+	if err != nil {
+		return nil, errors.Errorf("set DNSCONTROL_CREDS_KEY or DNSCONTROL_CREDS_KEY_FILE to the passphrase")
+	}
+	return config.NewCrypter(key)
+}
+
+// CredsEncrypt implements the creds encrypt subcommand.
+func CredsEncrypt(args CredsArgs) error {
+	dat, err := ioutil.ReadFile(args.CredsFile)
+	if err != nil {
+		return err
+	}
+	if config.IsEncryptedFile(dat) {
+		return errors.Errorf("%s is already encrypted", args.CredsFile)
+	}
+	c, err := newCredsCrypter()
+	if err != nil {
+		return err
+	}
+	if !args.Values && args.Keys == "" {
+		if dat, err = c.EncryptFile(dat); err != nil {
+			return err
+		}
+		return writeFile(args.output(), dat)
+	}
+
+	creds, err := config.ParseCreds(dat)
+	if err != nil {
+		return errors.Errorf("parsing %s: %s", args.CredsFile, err)
+	}
+	only := map[string]bool{}
+	for _, k := range strings.Split(args.Keys, ",") {
+		if k = strings.TrimSpace(k); k != "" {
+			only[k] = true
+		}
+	}
+	count := 0
+	creds.Each(func(section string, vals map[string]string) {
+		for k, v := range vals {
+			if err != nil || (len(only) > 0 && !only[k]) {
+				continue
+			}
+			if strings.HasPrefix(k, "_") || config.IsReference(vals, v) || v == "" || config.IsEncryptedValue(v) {
+				continue
+			}
+			vals[k], err = c.EncryptValue(v)
+			count++
+		}
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Encrypted %d values.\n", count)
+	return writeCreds(args.output(), creds)
+}
+
+// CredsDecrypt implements the creds decrypt subcommand.
+func CredsDecrypt(args CredsArgs) error {
+	dat, err := ioutil.ReadFile(args.CredsFile)
+	if err != nil {
+		return err
+	}
+	c, err := newCredsCrypter()
+	if err != nil {
+		return err
+	}
+	if config.IsEncryptedFile(dat) {
+		if dat, err = c.DecryptFile(dat); err != nil {
+			return err
+		}
+	}
+	creds, err := config.ParseCreds(dat)
+	if err != nil {
+		return errors.Errorf("parsing %s: %s", args.CredsFile, err)
+	}
+	count := 0
+	creds.Each(func(section string, vals map[string]string) {
+		for k, v := range vals {
+			if err != nil || !config.IsEncryptedValue(v) {
+				continue
+			}
+			if vals[k], err = c.DecryptValue(v); err != nil {
+				err = errors.Wrapf(err, "%s.%s", section, k)
+			}
+			count++
+		}
+	})
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		// Nothing else to do, so keep the file as written (including any comments).
+		return writeFile(args.output(), dat)
+	}
+	fmt.Printf("Decrypted %d values.\n", count)
+	return writeCreds(args.output(), creds)
+}
+
+func writeCreds(fname string, creds *config.Creds) error {
+	dat, err := json.MarshalIndent(creds, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFile(fname, append(dat, '\n'))
+}
+
+// writeFile replaces fname with dat. It writes to a temporary file in the same directory first,
+// so that a crash or a full disk never leaves a truncated credentials file. The mode of an
+// existing file is kept; a new file is only readable by its owner.
+func writeFile(fname string, dat []byte) error {
+	mode := os.FileMode(0600)
+	if st, err := os.Stat(fname); err == nil {
+		mode = st.Mode().Perm()
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(fname), "."+filepath.Base(fname)+".tmp")
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Write(dat)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Chmod(tmp.Name(), mode)
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), fname)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+	return err
+}
@@ -0,0 +1,35 @@
+package commands
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteFileReplacesAndKeepsMode(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dnscontrol-creds-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	fname := filepath.Join(dir, "creds.json")
+	if err := ioutil.WriteFile(fname, []byte(`{"old": {}}`), 0640); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(fname, 0640); err != nil {
+		t.Fatal(err)
+	}
+	if err := writeFile(fname, []byte(`{"new": {}}`)); err != nil {
+		t.Fatal(err)
+	}
+	if dat, _ := ioutil.ReadFile(fname); string(dat) != `{"new": {}}` {
+		t.Errorf("unexpected content %q", dat)
+	}
+	if st, err := os.Stat(fname); err != nil || st.Mode().Perm() != 0640 {
+		t.Errorf("expected mode 0640 to be kept, got %v %v", st.Mode(), err)
+	}
+	if files, _ := ioutil.ReadDir(dir); len(files) != 1 {
+		t.Errorf("expected no temporary files to be left, got %d files", len(files))
+	}
+}
@@ -29,6 +29,7 @@ var _ = cmd(catUtils, func() *cli.Command {
 type GetCertsArgs struct {
 	GetDNSConfigArgs
 	GetCredentialsArgs
+	FilterArgs
 
 	ACMEServer     string
 	CertsFile      string
@@ -49,6 +50,7 @@ type GetCertsArgs struct {
 func (args *GetCertsArgs) flags() []cli.Flag {
 	flags := args.GetDNSConfigArgs.flags()
 	flags = append(flags, args.GetCredentialsArgs.flags()...)
+	flags = append(flags, args.FilterArgs.domainFlags()...)
 
 	flags = append(flags, cli.StringFlag{
 		Name:        "acme",
@@ -139,7 +141,7 @@ func GetCerts(args GetCertsArgs) error {
 	if PrintValidationErrors(errs) {
 		return fmt.Errorf("Exiting due to validation errors")
 	}
-	notifier, err := InitializeProviders(args.CredsFile, cfg, args.Notify)
+	notifier, err := InitializeProviders(args.GetCredentialsArgs, cfg, args.Notify)
 	if err != nil {
 		return err
 	}
@@ -188,6 +190,9 @@ func GetCerts(args GetCertsArgs) error {
 		if args.Only != "" && cert.CertName != args.Only {
 			continue
 		}
+		if !args.certSelected(cert, cfg) {
+			continue
+		}
 		v := args.Verbose || printer.DefaultPrinter.Verbose
 		issued, err := client.IssueOrRenewCert(cert, args.RenewUnderDays, v)
 		if issued || err != nil {
@@ -201,6 +206,16 @@ func GetCerts(args GetCertsArgs) error {
 	return nil
 }
 
+// certSelected reports whether all names of the certificate are in domains selected by -domains and -tags.
+func (args *GetCertsArgs) certSelected(cert *acme.CertConfig, cfg *models.DNSConfig) bool {
+	for _, san := range cert.Names {
+		if d := cfg.DomainContainingFQDN(san); d == nil || !args.shouldRunDomain(d) {
+			return false
+		}
+	}
+	return true
+}
+
 var validCertNamesRegex = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_\-]*$`)
 
 func validateCertificateList(certs []*acme.CertConfig, cfg *models.DNSConfig) error {
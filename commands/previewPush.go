@@ -1,12 +1,20 @@
 package commands
 
 import (
+	"context"
 	"fmt"
-	"log"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/StackExchange/dnscontrol/models"
-	"github.com/StackExchange/dnscontrol/pkg/nameservers"
+	"github.com/StackExchange/dnscontrol/pkg/audit"
+	"github.com/StackExchange/dnscontrol/pkg/engine"
+	"github.com/StackExchange/dnscontrol/pkg/lock"
 	"github.com/StackExchange/dnscontrol/pkg/normalize"
 	"github.com/StackExchange/dnscontrol/pkg/notifications"
 	"github.com/StackExchange/dnscontrol/pkg/printer"
@@ -69,32 +77,242 @@ var _ = cmd(catMain, func() *cli.Command {
 // PushArgs contains all data/flags needed to run push, independently of CLI
 type PushArgs struct {
 	PreviewArgs
-	Interactive bool
+	LockArgs
+	Interactive  bool
+	AuditLog     string
+	StateFile    string
+	Resume       bool
+	Retries      int
+	RetryBackoff time.Duration
 }
 
 func (args *PushArgs) flags() []cli.Flag {
 	flags := args.PreviewArgs.flags()
+	flags = append(flags, args.LockArgs.flags()...)
 	flags = append(flags, cli.BoolFlag{
 		Name:        "i",
 		Destination: &args.Interactive,
 		Usage:       "Interactive. Confirm or Exclude each correction before they run",
 	})
+	flags = append(flags, cli.StringFlag{
+		Name:        "audit-log",
+		Destination: &args.AuditLog,
+		EnvVar:      "DNSCONTROL_AUDIT_LOG",
+		Usage:       "Append a record of every correction to this file (JSON lines)",
+	})
+	flags = append(flags, cli.StringFlag{
+		Name:        "state-file",
+		Destination: &args.StateFile,
+		Usage:       "File to record which corrections succeeded and failed, for -resume (default " + defaultStateFile + ", only written if something failed)",
+	})
+	flags = append(flags, cli.BoolFlag{
+		Name:        "resume",
+		Destination: &args.Resume,
+		Usage:       "Only push the domains that failed in the previous push, retrying failed corrections",
+	})
+	flags = append(flags, cli.IntFlag{
+		Name:        "retries",
+		Destination: &args.Retries,
+		Value:       3,
+		Usage:       "With -resume, how many times to retry a failed correction",
+	})
+	flags = append(flags, cli.DurationFlag{
+		Name:        "retry-backoff",
+		Destination: &args.RetryBackoff,
+		Value:       time.Second,
+		Usage:       "With -resume, how long to wait before the first retry. Doubles after each retry",
+	})
 	return flags
 }
 
+// LockArgs encapsulates the flags/args for sub-commands that lock zones while changing them.
+type LockArgs struct {
+	Lock            string
+	LockDir         string
+	LockVaultPath   string
+	LockStaleAfter  time.Duration
+	BreakStaleLocks bool
+}
+
+func (args *LockArgs) flags() []cli.Flag {
+	return []cli.Flag{
+		cli.StringFlag{
+			Name:        "lock",
+			Destination: &args.Lock,
+			Value:       "file",
+			Usage:       `How to lock each domain while pushing: file, vault or none`,
+		},
+		cli.StringFlag{
+			Name:        "lock-dir",
+			Destination: &args.LockDir,
+			Value:       filepath.Join(os.TempDir(), "dnscontrol-locks"),
+			Usage:       `Directory for lock files (with -lock=file)`,
+		},
+		cli.StringFlag{
+			Name:        "lock-vault-path",
+			Destination: &args.LockVaultPath,
+			Value:       "/secret/dnscontrol/locks",
+			Usage:       `Path in vault to store locks (with -lock=vault)`,
+		},
+		cli.DurationFlag{
+			Name:        "lock-stale-after",
+			Destination: &args.LockStaleAfter,
+			Value:       lock.DefaultStaleAfter,
+			Usage:       `Consider locks older than this stale`,
+		},
+		cli.BoolFlag{
+			Name:        "break-stale-locks",
+			Destination: &args.BreakStaleLocks,
+			Usage:       `Take over stale locks instead of failing`,
+		},
+	}
+}
+
+// Locker creates the lock.Locker selected by the flags.
+func (args *LockArgs) Locker() (lock.Locker, error) {
+	switch args.Lock {
+	case "file":
+		return lock.NewFileLocker(args.LockDir, args.LockStaleAfter, args.BreakStaleLocks)
+	case "vault":
+		return lock.NewVaultLocker(args.LockVaultPath, args.LockStaleAfter, args.BreakStaleLocks)
+	case "none", "":
+		return lock.None{}, nil
+	}
+	return nil, errors.Errorf("unknown lock type %q (must be file, vault or none)", args.Lock)
+}
+
 // Preview implements the preview subcommand.
 func Preview(args PreviewArgs) error {
-	return run(args, false, false, printer.DefaultPrinter)
+	return run(args, nil, printer.DefaultPrinter)
 }
 
+// defaultStateFile is where push records its state if -state-file is not given.
+const defaultStateFile = "dnscontrol-push-state.json"
+
 // Push implements the push subcommand.
 func Push(args PushArgs) error {
-	return run(args.PreviewArgs, true, args.Interactive, printer.DefaultPrinter)
+	var err error
+	opts := &pushOptions{interactive: args.Interactive, state: engine.NewState()}
+	if opts.locker, err = args.Locker(); err != nil {
+		return err
+	}
+	stateFile := args.StateFile
+	if stateFile == "" {
+		stateFile = defaultStateFile
+	}
+	previous, err := engine.LoadState(stateFile)
+	if err != nil {
+		if args.Resume {
+			return err
+		}
+		printer.Warnf("%s; it will be replaced\n", err)
+		previous = engine.NewState()
+	}
+	if args.Resume {
+		failed := previous.FailedDomains()
+		if len(failed) == 0 {
+			printer.Printf("Nothing to resume: the previous push completed without errors.\n")
+			return nil
+		}
+		printer.Printf("Resuming push of %s\n", strings.Join(failed, ", "))
+		opts.onlyDomains = map[string]bool{}
+		for _, d := range failed {
+			opts.onlyDomains[d] = true
+		}
+		opts.retries = args.Retries
+		opts.retryBackoff = args.RetryBackoff
+	}
+	if args.AuditLog != "" {
+		cfgFile := args.JSFile
+		if args.JSONFile != "" {
+			cfgFile = args.JSONFile
+		}
+		if opts.auditLog, err = audit.Open(args.AuditLog, cfgFile); err != nil {
+			return err
+		}
+		defer opts.auditLog.Close()
+	}
+	err = run(args.PreviewArgs, opts, printer.DefaultPrinter)
+	if args.Resume {
+		printPermanentFailures(opts.state)
+	}
+	// If run failed before the configuration was validated, keep the previous state, so that
+	// its failures can still be resumed.
+	if opts.configDomains != nil {
+		// Keep the failures of the domains that were not pushed this time (see -domains),
+		// unless they were removed from the configuration.
+		for name := range previous.Domains {
+			if !opts.configDomains[name] {
+				delete(previous.Domains, name)
+			}
+		}
+		opts.state.Merge(previous)
+		if serr := saveState(opts.state, args.StateFile); serr != nil {
+			printer.Warnf("Saving push state: %s\n", serr)
+		}
+	}
+	return err
+}
+
+// saveState writes the state to file. Without a file, the state is only written to
+// defaultStateFile if something failed, and the file of an earlier push is removed once
+// nothing needs resuming.
+func saveState(state *engine.State, file string) error {
+	if file != "" {
+		return state.Save(file)
+	}
+	if len(state.FailedDomains()) != 0 {
+		return state.Save(defaultStateFile)
+	}
+	if err := os.Remove(defaultStateFile); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
 }
 
-// run is the main routine common to preview/push
-func run(args PreviewArgs, push bool, interactive bool, out printer.CLI) error {
-	// TODO: make truly CLI independent. Perhaps return results on a channel as they occur
+// pushOptions holds everything run needs to push, in addition to the PreviewArgs.
+type pushOptions struct {
+	interactive bool
+	locker      lock.Locker
+	auditLog    *audit.Log
+	state       *engine.State
+	onlyDomains map[string]bool // if set, only push these domains (for -resume)
+	// configDomains is set by run to the domains of the configuration, once it is valid.
+	configDomains map[string]bool
+	retries       int
+	retryBackoff  time.Duration
+}
+
+// printPermanentFailures lists the corrections that still failed after being retried.
+func printPermanentFailures(state *engine.State) {
+	for _, domain := range state.FailedDomains() {
+		d := state.Domains[domain]
+		if d.Error != "" {
+			printer.Printf("PERMANENT FAILURE: %s: %s\n", domain, d.Error)
+		}
+		if d.Pending {
+			printer.Printf("NOT COMPLETED: %s\n", domain)
+		}
+		names := []string{}
+		for name := range d.Providers {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			p := d.Providers[name]
+			if p.Error != "" {
+				printer.Printf("PERMANENT FAILURE: %s[%s]: %s\n", domain, name, p.Error)
+			}
+			for _, f := range p.Failed {
+				printer.Printf("PERMANENT FAILURE: %s[%s] after %d attempts: %s\n    %s\n", domain, name, f.Attempts, f.Error, f.Msg)
+			}
+		}
+	}
+}
+
+// run is the main routine common to preview/push. It is a thin client of the engine package.
+// push is nil for preview.
+func run(args PreviewArgs, push *pushOptions, out printer.CLI) error {
 	cfg, err := GetDNSConfig(args.GetDNSConfigArgs)
 	if err != nil {
 		return err
@@ -103,70 +321,121 @@ func run(args PreviewArgs, push bool, interactive bool, out printer.CLI) error {
 	if PrintValidationErrors(errs) {
 		return errors.Errorf("Exiting due to validation errors")
 	}
-	// TODO:
-	notifier, err := InitializeProviders(args.CredsFile, cfg, args.Notify)
+	notifier, err := InitializeProviders(args.GetCredentialsArgs, cfg, args.Notify)
 	if err != nil {
 		return err
 	}
-	anyErrors := false
-	totalCorrections := 0
-DomainLoop:
-	for _, domain := range cfg.Domains {
-		if !args.shouldRunDomain(domain.Name) {
-			continue
-		}
-		out.StartDomain(domain.Name)
-		nsList, err := nameservers.DetermineNameservers(domain)
-		if err != nil {
-			return err
+	opts := engine.Options{
+		Synchronous: true, // keep our output in order with what providers print
+		ShouldRunDomain: func(dc *models.DomainConfig) bool {
+			return args.shouldRunDomain(dc)
+		},
+		ShouldRunProvider: args.shouldRunProvider,
+	}
+	var auditLog *audit.Log
+	if push != nil {
+		opts.Push = true
+		opts.Interactive = push.interactive
+		opts.Locker = push.locker
+		opts.Retries = push.retries
+		opts.RetryBackoff = push.retryBackoff
+		if push.onlyDomains != nil {
+			opts.ShouldRunDomain = func(dc *models.DomainConfig) bool {
+				return push.onlyDomains[dc.UniqueName()] && args.shouldRunDomain(dc)
+			}
 		}
-		domain.Nameservers = nsList
-		nameservers.AddNSRecords(domain)
-		for _, provider := range domain.DNSProviderInstances {
-			dc, err := domain.Copy()
-			if err != nil {
-				return err
+		auditLog = push.auditLog
+		push.configDomains = map[string]bool{}
+		for _, dc := range cfg.Domains {
+			push.configDomains[dc.UniqueName()] = true
+			if opts.ShouldRunDomain(dc) {
+				push.state.Expect(dc.UniqueName())
 			}
-			shouldrun := args.shouldRunProvider(provider.Name, dc)
-			out.StartDNSProvider(provider.Name, !shouldrun)
-			if !shouldrun {
-				continue
+		}
+	}
+	ctx, stop := interruptContext()
+	defer stop()
+	results, err := engine.Run(ctx, cfg, opts)
+	if err != nil {
+		return err
+	}
+	anyErrors := false
+	totalCorrections := 0
+	handleResult := func(res engine.Result) error {
+		switch res.Type {
+		case engine.DomainStarted:
+			out.StartDomain(res.Domain)
+		case engine.ProviderStarted:
+			if res.IsRegistrar {
+				out.StartRegistrar(res.Provider, res.Skipped)
+			} else {
+				out.StartDNSProvider(res.Provider, res.Skipped)
 			}
-			corrections, err := provider.Driver.GetDomainCorrections(dc)
-			out.EndProvider(len(corrections), err)
-			if err != nil {
+		case engine.ProviderCompleted:
+			out.EndProvider(len(res.Corrections), res.Err)
+			if res.Err != nil {
 				anyErrors = true
-				continue DomainLoop
+				// Corrections refused because the credentials are read-only.
+				for i, c := range res.Corrections {
+					out.PrintCorrection(i, c)
+				}
+				msg := "Could not get corrections"
+				if len(res.Corrections) != 0 {
+					msg = fmt.Sprintf("%d corrections not run", len(res.Corrections))
+				}
+				notifier.Notify(res.Domain, res.Provider, msg, res.Err, push == nil)
 			}
-			totalCorrections += len(corrections)
-			anyErrors = printOrRunCorrections(domain.Name, provider.Name, corrections, out, push, interactive, notifier) || anyErrors
-		}
-		run := args.shouldRunProvider(domain.RegistrarName, domain)
-		out.StartRegistrar(domain.RegistrarName, !run)
-		if !run {
-			continue
-		}
-		if len(domain.Nameservers) == 0 && domain.Metadata["no_ns"] != "true" {
-			out.Warnf("No nameservers declared; skipping registrar. Add {no_ns:'true'} to force.\n")
-			continue
+			totalCorrections += len(res.Corrections)
+		case engine.CorrectionPlanned:
+			out.PrintCorrection(res.Index, res.Correction)
+			if res.Approve != nil {
+				res.Approve <- out.PromptToRun()
+			}
+		case engine.CorrectionCompleted:
+			if auditLog != nil {
+				if err := auditLog.Record(res.Domain, res.Provider, res.Correction, res.Ran, res.Err); err != nil {
+					out.Warnf("%s\n", err)
+					anyErrors = true
+				}
+			}
+			if res.Skipped {
+				return nil
+			}
+			if res.Ran {
+				out.EndCorrection(res.Err)
+				if res.Err != nil {
+					anyErrors = true
+				}
+			}
+			notifier.Notify(res.Domain, res.Provider, res.Correction.Msg, res.Err, !res.Ran)
+		case engine.DomainFailed:
+			out.Printf("ERROR: %s\n", res.Err)
+			anyErrors = true
+			notifier.Notify(res.Domain, res.Provider, "Domain not processed", res.Err, push == nil)
+		case engine.Warning:
+			out.Warnf("%s\n", res.Message)
+		case engine.Aborted:
+			return res.Err
 		}
-		dc, err := domain.Copy()
-		if err != nil {
-			log.Fatal(err)
+		return nil
+	}
+	for res := range results {
+		if push != nil {
+			push.state.Record(res)
 		}
-		corrections, err := domain.RegistrarInstance.Driver.GetRegistrarCorrections(dc)
-		out.EndProvider(len(corrections), err)
+		err := handleResult(res)
+		res.Done()
 		if err != nil {
-			anyErrors = true
-			continue
+			return err
 		}
-		totalCorrections += len(corrections)
-		anyErrors = printOrRunCorrections(domain.Name, domain.RegistrarName, corrections, out, push, interactive, notifier) || anyErrors
 	}
 	if os.Getenv("TEAMCITY_VERSION") != "" {
 		fmt.Fprintf(os.Stderr, "##teamcity[buildStatus status='SUCCESS' text='%d corrections']", totalCorrections)
 	}
 	notifier.Done()
+	if ctx.Err() != nil {
+		return errors.Errorf("Interrupted")
+	}
 	out.Printf("Done. %d corrections.\n", totalCorrections)
 	if anyErrors {
 		return errors.Errorf("Completed with errors")
@@ -177,20 +446,63 @@ DomainLoop:
 	return nil
 }
 
-// InitializeProviders takes a creds file path and a DNSConfig object. Creates all providers with the proper types, and returns them.
+// interruptContext returns a context that is cancelled on the first SIGINT or SIGTERM, so that
+// the engine stops after the current correction and releases its locks. Later signals have their
+// usual effect. Call stop once the context is no longer needed.
+func interruptContext() (ctx context.Context, stop func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		select {
+		case <-sigs:
+			signal.Stop(sigs)
+			printer.Warnf("Interrupted: stopping after the current correction and releasing locks. Interrupt again to quit at once.\n")
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, func() {
+		signal.Stop(sigs)
+		cancel()
+	}
+}
+
+// InitializeProviders takes the creds file flags and a DNSConfig object. Creates all providers with the proper types, and returns them.
 // nonDefaultProviders is a list of providers that should not be run unless explicitly asked for by flags.
-func InitializeProviders(credsFile string, cfg *models.DNSConfig, notifyFlag bool) (notify notifications.Notifier, err error) {
+func InitializeProviders(creds GetCredentialsArgs, cfg *models.DNSConfig, notifyFlag bool) (notify notifications.Notifier, err error) {
 	var providerConfigs map[string]map[string]string
-	var notificationCfg map[string]string
 	defer func() {
-		notify = notifications.Init(notificationCfg)
+		if notify == nil {
+			notify, _ = notifications.Init(nil) // can't fail without a config
+		}
 	}()
-	providerConfigs, err = config.LoadProviderConfigs(credsFile)
+	providerConfigs, err = config.ReadProviderConfigs(creds.CredsFile, creds.Profile)
 	if err != nil {
 		return
 	}
+	// Only resolve the secrets that will be used, so that unused providers need not be configured.
+	used := []string{}
+	for _, d := range cfg.Domains {
+		used = append(used, d.RegistrarName)
+		for _, pInst := range d.DNSProviderInstances {
+			used = append(used, pInst.Name)
+		}
+	}
 	if notifyFlag {
-		notificationCfg = providerConfigs["notifications"]
+		for name := range providerConfigs {
+			if name == "notifications" || strings.HasPrefix(name, "notifications.") {
+				used = append(used, name)
+			}
+		}
+	}
+	if err = config.ResolveReferences(providerConfigs, used...); err != nil {
+		return nil, errors.Errorf("While reading provider credentials file %v: %v", creds.CredsFile, err)
+	}
+	if notifyFlag {
+		if notify, err = notifications.InitRoutes(providerConfigs); err != nil {
+			return nil, err
+		}
 	}
 	isNonDefault := map[string]bool{}
 	for name, vals := range providerConfigs {
@@ -213,6 +525,7 @@ func InitializeProviders(credsFile string, cfg *models.DNSConfig, notifyFlag boo
 		}
 		d.RegistrarInstance.Driver = registrars[d.RegistrarName]
 		d.RegistrarInstance.IsDefault = !isNonDefault[d.RegistrarName]
+		setCredentialLimits(&d.RegistrarInstance.ProviderBase, providerConfigs[d.RegistrarName])
 		for _, pInst := range d.DNSProviderInstances {
 			if dnsProviders[pInst.Name] == nil {
 				dCfg := cfg.DNSProvidersByName[pInst.Name]
@@ -224,30 +537,21 @@ func InitializeProviders(credsFile string, cfg *models.DNSConfig, notifyFlag boo
 			}
 			pInst.Driver = dnsProviders[pInst.Name]
 			pInst.IsDefault = !isNonDefault[pInst.Name]
+			setCredentialLimits(&pInst.ProviderBase, providerConfigs[pInst.Name])
 		}
 	}
 	return
 }
 
-func printOrRunCorrections(domain string, provider string, corrections []*models.Correction, out printer.CLI, push bool, interactive bool, notifier notifications.Notifier) (anyErrors bool) {
-	anyErrors = false
-	if len(corrections) == 0 {
-		return false
-	}
-	for i, correction := range corrections {
-		out.PrintCorrection(i, correction)
-		var err error
-		if push {
-			if interactive && !out.PromptToRun() {
-				continue
-			}
-			err = correction.F()
-			out.EndCorrection(err)
-			if err != nil {
-				anyErrors = true
-			}
+// setCredentialLimits applies the restrictions of a creds.json entry, which the engine enforces:
+// "_allowed_domains" is a comma separated list of domain globs the credentials may be used for,
+// and "_read_only":"true" allows preview but not push.
+func setCredentialLimits(p *models.ProviderBase, vals map[string]string) {
+	p.AllowedDomains = nil
+	for _, pattern := range strings.Split(vals["_allowed_domains"], ",") {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			p.AllowedDomains = append(p.AllowedDomains, pattern)
 		}
-		notifier.Notify(domain, provider, correction.Msg, err, !push)
 	}
-	return anyErrors
+	p.ReadOnly = vals["_read_only"] == "true"
 }
@@ -0,0 +1,65 @@
+package commands
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/pkg/printer"
+)
+
+func TestProviderErrorsAreNotified(t *testing.T) {
+	var mu sync.Mutex
+	var messages []map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		m := map[string]interface{}{}
+		json.NewDecoder(r.Body).Decode(&m)
+		mu.Lock()
+		messages = append(messages, m)
+		mu.Unlock()
+	}))
+	defer srv.Close()
+
+	dir, err := ioutil.TempDir("", "dnscontrol-notify-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	write := func(name, content string) string {
+		p := filepath.Join(dir, name)
+		if err := ioutil.WriteFile(p, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+	js := write("dnsconfig.js", `
+var REG = NewRegistrar("none", "NONE");
+var BIND = NewDnsProvider("bind", "BIND");
+D("ok.com", REG, DnsProvider(BIND), A("@", "1.2.3.4"));
+D("other.com", REG, DnsProvider(BIND), A("@", "1.2.3.4"));
+`)
+	creds := write("creds.json", `{
+  "bind": {"directory": "`+filepath.Join(dir, "zones")+`", "_allowed_domains": "ok.com"},
+  "notifications.oncall": {"_events": "errors", "_providers": "bind", "webhook_url": "`+srv.URL+`"}
+}`)
+
+	args := PreviewArgs{Notify: true}
+	args.JSFile = js
+	args.CredsFile = creds
+	if err := run(args, nil, printer.DefaultPrinter); err == nil {
+		t.Fatal("expected the preview to fail for other.com")
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(messages) != 1 {
+		t.Fatalf("expected 1 notification, got %v", messages)
+	}
+	if m := messages[0]; m["domain"] != "other.com" || m["provider"] != "bind" || m["error"] == "" {
+		t.Errorf("expected the refused credentials of other.com to be notified, got %v", m)
+	}
+}
@@ -101,7 +101,11 @@ func ExecuteDSL(args ExecuteDSLArgs) (*models.DNSConfig, error) {
 		return nil, errors.Errorf("No config specified")
 	}
 
-	dnsConfig, err := js.ExecuteJavascript(args.JSFile, args.DevMode)
+	vars, err := args.variables()
+	if err != nil {
+		return nil, err
+	}
+	dnsConfig, err := js.ExecuteJavascript(args.JSFile, args.DevMode, vars, args.limits())
 	if err != nil {
 		return nil, errors.Errorf("Executing javascript in %s: %s", args.JSFile, err)
 	}
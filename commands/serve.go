@@ -0,0 +1,419 @@
+package commands
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/models"
+	"github.com/StackExchange/dnscontrol/pkg/engine"
+	"github.com/StackExchange/dnscontrol/pkg/lock"
+	"github.com/StackExchange/dnscontrol/pkg/normalize"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+)
+
+var _ = cmd(catUtils, func() *cli.Command {
+	var args ServeArgs
+	return &cli.Command{
+		Name:  "serve",
+		Usage: "run an HTTP API that checks, previews and pushes configurations on request",
+		Action: func(ctx *cli.Context) error {
+			return exit(Serve(args))
+		},
+		Flags: args.flags(),
+	}
+}())
+
+// ServeArgs contains all data/flags needed to run serve, independently of CLI.
+type ServeArgs struct {
+	GetCredentialsArgs
+	LockArgs
+	JSLimitsArgs
+	UnsafeJS bool
+	Listen   string
+	DevMode  bool
+}
+
+// Limits of posted configurations, unless -unsafe-js is given.
+const (
+	defaultServeJSTimeout   = 10 * time.Second
+	defaultServeJSMaxMemory = 256 // MiB
+)
+
+func (args *ServeArgs) flags() []cli.Flag {
+	flags := args.GetCredentialsArgs.flags()
+	flags = append(flags, args.LockArgs.flags()...)
+	flags = append(flags, cli.DurationFlag{
+		Name:        "js-timeout",
+		Destination: &args.JSTimeout,
+		Value:       defaultServeJSTimeout,
+		Usage:       "Stop a posted dnsconfig.js if it runs longer than this",
+	})
+	flags = append(flags, cli.IntFlag{
+		Name:        "js-max-memory",
+		Destination: &args.JSMaxMemory,
+		Value:       defaultServeJSMaxMemory,
+		Usage:       "Stop a posted dnsconfig.js if it uses more than this many MiB of memory",
+	})
+	flags = append(flags, cli.BoolFlag{
+		Name:        "unsafe-js",
+		Destination: &args.UnsafeJS,
+		Usage:       "Run posted configurations without the sandbox, timeout and memory limit",
+	})
+	flags = append(flags, cli.StringFlag{
+		Name:        "listen",
+		Destination: &args.Listen,
+		Value:       "127.0.0.1:8053",
+		Usage:       "Address to listen on",
+	})
+	flags = append(flags, cli.BoolFlag{
+		Name:        "dev",
+		Destination: &args.DevMode,
+		Usage:       "Use helpers.js from disk instead of embedded copy",
+	})
+	return flags
+}
+
+// jsLimits returns the limits of posted configurations: they run in the sandbox, with a timeout and
+// a memory limit (the defaults if not set), unless UnsafeJS is set.
+func (args *ServeArgs) jsLimits() JSLimitsArgs {
+	if args.UnsafeJS {
+		return JSLimitsArgs{}
+	}
+	limits := JSLimitsArgs{JSTimeout: args.JSTimeout, JSMaxMemory: args.JSMaxMemory, Sandbox: true}
+	if limits.JSTimeout <= 0 {
+		limits.JSTimeout = defaultServeJSTimeout
+	}
+	if limits.JSMaxMemory <= 0 {
+		limits.JSMaxMemory = defaultServeJSMaxMemory
+	}
+	return limits
+}
+
+// Serve implements the serve subcommand.
+//
+// Endpoints (all POST, body is a dnsconfig.js file, or IR json if Content-Type is application/json):
+//
+//	/check    validate the configuration.
+//	/preview  validate and return the corrections for each domain and provider, plus a plan hash.
+//	/push     like preview, then run the corrections. Requires ?plan=HASH matching the current plan.
+//
+// /preview and /push accept ?domains=, ?tags= and ?providers= with the same meaning as the CLI flags.
+func Serve(args ServeArgs) error {
+	locker, err := args.Locker()
+	if err != nil {
+		return err
+	}
+	s := newServer(args, locker)
+	log.Printf("Listening on %s", args.Listen)
+	return http.ListenAndServe(args.Listen, s.handler())
+}
+
+// maxConfigSize is the largest configuration that can be posted.
+const maxConfigSize = 10 << 20
+
+type server struct {
+	args   ServeArgs
+	locker lock.Locker // coordinates with pushes outside this server
+
+	// The javascript interpreter keeps global state (see pkg/js currentDirectory),
+	// so only one configuration is executed at a time.
+	dslMu sync.Mutex
+
+	zonesMu sync.Mutex
+	zones   map[string]bool // zones with a push in progress
+}
+
+func newServer(args ServeArgs, locker lock.Locker) *server {
+	return &server{args: args, locker: locker, zones: map[string]bool{}}
+}
+
+func (s *server) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/check", s.handle(s.check))
+	mux.HandleFunc("/preview", s.handle(s.preview))
+	mux.HandleFunc("/push", s.handle(s.push))
+	return mux
+}
+
+// serveError is an error with an HTTP status code.
+type serveError struct {
+	status int
+	err    error
+}
+
+func (e *serveError) Error() string { return e.err.Error() }
+
+func statusErrorf(status int, format string, args ...interface{}) error {
+	return &serveError{status: status, err: errors.Errorf(format, args...)}
+}
+
+// serveResponse is the json body returned by every endpoint.
+type serveResponse struct {
+	Errors   []string             `json:"errors,omitempty"`
+	Warnings []string             `json:"warnings,omitempty"`
+	Results  []*serveDomainResult `json:"results,omitempty"`
+	Plan     string               `json:"plan,omitempty"`
+	Error    string               `json:"error,omitempty"`
+}
+
+type serveDomainResult struct {
+	Domain      string             `json:"domain"`
+	Provider    string             `json:"provider"`
+	Registrar   bool               `json:"registrar,omitempty"`
+	Skipped     bool               `json:"skipped,omitempty"`
+	Warning     string             `json:"warning,omitempty"`
+	Error       string             `json:"error,omitempty"`
+	Corrections []*serveCorrection `json:"corrections,omitempty"`
+}
+
+type serveCorrection struct {
+	Message string `json:"message"`
+	Ran     bool   `json:"ran,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+func (s *server) handle(f func(r *http.Request, resp *serveResponse) error) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		r.Body = http.MaxBytesReader(w, r.Body, maxConfigSize)
+		resp := &serveResponse{}
+		status := http.StatusOK
+		if err := f(r, resp); err != nil {
+			status = http.StatusInternalServerError
+			if se, ok := err.(*serveError); ok {
+				status = se.status
+			}
+			resp.Error = err.Error()
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(resp); err != nil {
+			log.Printf("Writing response: %s", err)
+		}
+	}
+}
+
+// loadConfig reads the posted configuration and validates it.
+func (s *server) loadConfig(r *http.Request, resp *serveResponse) (*models.DNSConfig, error) {
+	dir, err := ioutil.TempDir("", "dnscontrol-serve")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	args := GetDNSConfigArgs{ExecuteDSLArgs: ExecuteDSLArgs{DevMode: s.args.DevMode, JSLimitsArgs: s.args.jsLimits()}}
+	fname := filepath.Join(dir, "dnsconfig.js")
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		fname = filepath.Join(dir, "dnsconfig.json")
+		args.JSONFile = fname
+	} else {
+		args.JSFile = fname
+	}
+	f, err := os.Create(fname)
+	if err != nil {
+		return nil, err
+	}
+	n, err := io.Copy(f, r.Body)
+	f.Close()
+	if err != nil && n == maxConfigSize {
+		return nil, statusErrorf(http.StatusRequestEntityTooLarge, "configuration is larger than %d bytes", maxConfigSize)
+	}
+	if err != nil {
+		return nil, statusErrorf(http.StatusBadRequest, "reading request: %s", err)
+	}
+
+	s.dslMu.Lock()
+	cfg, err := GetDNSConfig(args)
+	s.dslMu.Unlock()
+	if err != nil {
+		return nil, statusErrorf(http.StatusBadRequest, "%s", err)
+	}
+	fatal := false
+	for _, err := range normalize.NormalizeAndValidateConfig(cfg) {
+		if _, ok := err.(normalize.Warning); ok {
+			resp.Warnings = append(resp.Warnings, err.Error())
+		} else {
+			fatal = true
+			resp.Errors = append(resp.Errors, err.Error())
+		}
+	}
+	if fatal {
+		return nil, statusErrorf(http.StatusUnprocessableEntity, "validation errors")
+	}
+	return cfg, nil
+}
+
+func (s *server) check(r *http.Request, resp *serveResponse) error {
+	_, err := s.loadConfig(r, resp)
+	return err
+}
+
+func (s *server) filter(r *http.Request) FilterArgs {
+	return FilterArgs{
+		Domains:   r.URL.Query().Get("domains"),
+		Tags:      r.URL.Query().Get("tags"),
+		Providers: r.URL.Query().Get("providers"),
+	}
+}
+
+func (s *server) preview(r *http.Request, resp *serveResponse) error {
+	cfg, err := s.loadConfig(r, resp)
+	if err != nil {
+		return err
+	}
+	if _, err = InitializeProviders(s.args.GetCredentialsArgs, cfg, false); err != nil {
+		return err
+	}
+	resp.Results, err = s.runEngine(r.Context(), cfg, s.filter(r), "")
+	resp.Plan = planHash(resp.Results)
+	return err
+}
+
+func (s *server) push(r *http.Request, resp *serveResponse) error {
+	plan := r.URL.Query().Get("plan")
+	if plan == "" {
+		return statusErrorf(http.StatusBadRequest, "push requires the plan parameter returned by preview")
+	}
+	cfg, err := s.loadConfig(r, resp)
+	if err != nil {
+		return err
+	}
+	if _, err = InitializeProviders(s.args.GetCredentialsArgs, cfg, false); err != nil {
+		return err
+	}
+	filter := s.filter(r)
+	zones := []string{}
+	for _, d := range cfg.Domains {
+		if filter.shouldRunDomain(d) {
+			zones = append(zones, d.UniqueName())
+		}
+	}
+	if err := s.lockZones(zones); err != nil {
+		return err
+	}
+	defer s.unlockZones(zones)
+
+	// On a conflict nothing is run, and the response has the new plan for the caller to review.
+	resp.Results, err = s.runEngine(r.Context(), cfg, filter, plan)
+	resp.Plan = planHash(resp.Results)
+	return err
+}
+
+// lockZones marks all zones as being pushed, or fails if any of them already is.
+func (s *server) lockZones(zones []string) error {
+	s.zonesMu.Lock()
+	defer s.zonesMu.Unlock()
+	for _, z := range zones {
+		if s.zones[z] {
+			return statusErrorf(http.StatusConflict, "a push is already running for %s", z)
+		}
+	}
+	for _, z := range zones {
+		s.zones[z] = true
+	}
+	return nil
+}
+
+func (s *server) unlockZones(zones []string) {
+	s.zonesMu.Lock()
+	defer s.zonesMu.Unlock()
+	for _, z := range zones {
+		delete(s.zones, z)
+	}
+}
+
+// runEngine previews the corrections, or pushes them if plan is set, and collects the results per
+// domain and provider. A push computes all corrections while holding the locks, and only runs them
+// if their hash matches plan, so that it runs exactly what the caller has seen.
+func (s *server) runEngine(ctx context.Context, cfg *models.DNSConfig, filter FilterArgs, plan string) ([]*serveDomainResult, error) {
+	results, err := engine.Run(ctx, cfg, engine.Options{
+		Push:      plan != "",
+		PlanFirst: true,
+		ShouldRunDomain: func(dc *models.DomainConfig) bool {
+			return filter.shouldRunDomain(dc)
+		},
+		ShouldRunProvider: filter.shouldRunProvider,
+		Locker:            s.locker,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var out []*serveDomainResult
+	providers := map[string]*serveDomainResult{}
+	key := func(res engine.Result) string {
+		return fmt.Sprintf("%s\x00%s\x00%v", res.Domain, res.Provider, res.IsRegistrar)
+	}
+	var conflict error
+	for res := range results {
+		switch res.Type {
+		case engine.ProviderStarted:
+			current := &serveDomainResult{Domain: res.Domain, Provider: res.Provider, Registrar: res.IsRegistrar, Skipped: res.Skipped}
+			providers[key(res)] = current
+			out = append(out, current)
+		case engine.ProviderCompleted:
+			current := providers[key(res)]
+			if res.Err != nil {
+				current.Error = res.Err.Error()
+			}
+			for _, c := range res.Corrections {
+				current.Corrections = append(current.Corrections, &serveCorrection{Message: c.Msg})
+			}
+		case engine.PlanComputed:
+			if planHash(out) != plan {
+				conflict = statusErrorf(http.StatusConflict, "plan has changed since preview")
+			}
+			res.Approve <- conflict == nil
+		case engine.CorrectionCompleted:
+			c := providers[key(res)].Corrections[res.Index]
+			c.Ran = res.Ran
+			if res.Err != nil {
+				c.Error = res.Err.Error()
+			}
+		case engine.DomainFailed:
+			out = append(out, &serveDomainResult{Domain: res.Domain, Error: res.Err.Error()})
+		case engine.Warning:
+			current := &serveDomainResult{Domain: res.Domain, Provider: res.Provider, Registrar: res.IsRegistrar, Warning: res.Message}
+			if p := providers[key(res)]; p != nil && p.Warning == "" {
+				p.Warning = res.Message
+			} else {
+				out = append(out, current)
+			}
+		case engine.Aborted:
+			if conflict != nil {
+				return out, conflict
+			}
+			return out, res.Err
+		}
+	}
+	return out, ctx.Err()
+}
+
+// planHash identifies a set of corrections so that a push can verify that it runs what was previewed.
+func planHash(results []*serveDomainResult) string {
+	h := sha256.New()
+	for _, r := range results {
+		for _, c := range r.Corrections {
+			fmt.Fprintf(h, "%s\x00%s\x00%s\n", r.Domain, r.Provider, c.Message)
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,180 @@
+package commands
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/pkg/lock"
+	_ "github.com/StackExchange/dnscontrol/providers/bind"
+)
+
+const serveTestConfig = `
+var REG = NewRegistrar("none", "NONE");
+var BIND = NewDnsProvider("bind", "BIND");
+D("example.com", REG, DnsProvider(BIND), A("@", "1.2.3.4"));
+`
+
+// newTestServer returns a server that pushes to BIND zone files in a temporary directory.
+// Call the returned function to stop it.
+func newTestServer(t *testing.T) (*server, *httptest.Server, string, func()) {
+	dir, err := ioutil.TempDir("", "dnscontrol-serve-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	zones := filepath.Join(dir, "zones")
+	if err := os.Mkdir(zones, 0755); err != nil {
+		t.Fatal(err)
+	}
+	creds := filepath.Join(dir, "creds.json")
+	if err := ioutil.WriteFile(creds, []byte(`{"bind": {"directory": "`+zones+`"}}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	s := newServer(ServeArgs{GetCredentialsArgs: GetCredentialsArgs{CredsFile: creds}}, lock.None{})
+	ts := httptest.NewServer(s.handler())
+	return s, ts, zones, func() {
+		ts.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func post(t *testing.T, ts *httptest.Server, path, body string) (int, *serveResponse) {
+	res, err := http.Post(ts.URL+path, "application/javascript", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	resp := &serveResponse{}
+	if err := json.NewDecoder(res.Body).Decode(resp); err != nil {
+		t.Fatal(err)
+	}
+	return res.StatusCode, resp
+}
+
+func countCorrections(resp *serveResponse, ran bool) int {
+	n := 0
+	for _, r := range resp.Results {
+		for _, c := range r.Corrections {
+			if c.Ran == ran {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+func TestServeCheck(t *testing.T) {
+	_, ts, _, stop := newTestServer(t)
+	defer stop()
+	if status, resp := post(t, ts, "/check", serveTestConfig); status != http.StatusOK || resp.Error != "" {
+		t.Errorf("check: got %d %+v", status, resp)
+	}
+	if status, resp := post(t, ts, "/check", `D("example.com", REG_MISSING);`); status != http.StatusBadRequest || resp.Error == "" {
+		t.Errorf("check of a broken configuration: got %d %+v", status, resp)
+	}
+	res, err := http.Get(ts.URL + "/check")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("GET: expected %d, got %d", http.StatusMethodNotAllowed, res.StatusCode)
+	}
+}
+
+func TestServeConfigTooLarge(t *testing.T) {
+	_, ts, _, stop := newTestServer(t)
+	defer stop()
+	body := serveTestConfig + "//" + strings.Repeat("x", maxConfigSize)
+	if status, _ := post(t, ts, "/check", body); status != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected %d, got %d", http.StatusRequestEntityTooLarge, status)
+	}
+}
+
+func TestServePreviewAndPush(t *testing.T) {
+	_, ts, zones, stop := newTestServer(t)
+	defer stop()
+	zonefile := filepath.Join(zones, "example.com.zone")
+
+	status, preview := post(t, ts, "/preview", serveTestConfig)
+	if status != http.StatusOK || preview.Plan == "" || countCorrections(preview, false) == 0 {
+		t.Fatalf("preview: got %d %+v", status, preview)
+	}
+	if _, err := os.Stat(zonefile); !os.IsNotExist(err) {
+		t.Fatal("preview wrote the zone")
+	}
+
+	// A stale plan runs nothing and returns the current plan.
+	status, resp := post(t, ts, "/push?plan=stale", serveTestConfig)
+	if status != http.StatusConflict || resp.Plan != preview.Plan || countCorrections(resp, true) != 0 {
+		t.Fatalf("push with a stale plan: got %d %+v", status, resp)
+	}
+	if _, err := os.Stat(zonefile); !os.IsNotExist(err) {
+		t.Fatal("push with a stale plan wrote the zone")
+	}
+
+	status, resp = post(t, ts, "/push?plan="+preview.Plan, serveTestConfig)
+	if status != http.StatusOK || resp.Error != "" || countCorrections(resp, true) != countCorrections(preview, false) {
+		t.Fatalf("push: got %d %+v", status, resp)
+	}
+	if _, err := os.Stat(zonefile); err != nil {
+		t.Fatalf("push did not write the zone: %s", err)
+	}
+
+	// Pushing the same plan again conflicts: the zone is now up to date.
+	if status, _ := post(t, ts, "/push?plan="+preview.Plan, serveTestConfig); status != http.StatusConflict {
+		t.Errorf("second push of the same plan: expected %d, got %d", http.StatusConflict, status)
+	}
+}
+
+func TestServePushZoneConflict(t *testing.T) {
+	s, ts, zones, stop := newTestServer(t)
+	defer stop()
+	_, preview := post(t, ts, "/preview", serveTestConfig)
+
+	if err := s.lockZones([]string{"example.com"}); err != nil {
+		t.Fatal(err)
+	}
+	status, resp := post(t, ts, "/push?plan="+preview.Plan, serveTestConfig)
+	if status != http.StatusConflict || !strings.Contains(resp.Error, "already running") {
+		t.Fatalf("push during another push: got %d %+v", status, resp)
+	}
+	if _, err := os.Stat(filepath.Join(zones, "example.com.zone")); !os.IsNotExist(err) {
+		t.Fatal("push during another push wrote the zone")
+	}
+	s.unlockZones([]string{"example.com"})
+
+	if status, resp := post(t, ts, "/push?plan="+preview.Plan, serveTestConfig); status != http.StatusOK {
+		t.Fatalf("push after the other push finished: got %d %+v", status, resp)
+	}
+}
+
+func TestServeLimitsPostedConfigs(t *testing.T) {
+	s, ts, _, stop := newTestServer(t)
+	defer stop()
+	s.args.JSTimeout = 100 * time.Millisecond
+
+	for _, config := range []string{
+		`require("` + s.args.CredsFile + `");`,
+		`require("/etc/hostname");`,
+	} {
+		status, resp := post(t, ts, "/check", config)
+		if status != http.StatusBadRequest || !strings.Contains(resp.Error, "sandbox mode") {
+			t.Errorf("%s: expected the sandbox to refuse, got %d %+v", config, status, resp)
+		}
+	}
+
+	if status, resp := post(t, ts, "/check", `while (true) {}`); status != http.StatusBadRequest || !strings.Contains(resp.Error, "ran longer than") {
+		t.Errorf("endless loop: expected a timeout, got %d %+v", status, resp)
+	}
+	// The server is still usable after the timeout.
+	if status, resp := post(t, ts, "/check", serveTestConfig); status != http.StatusOK {
+		t.Errorf("check after a timeout: got %d %+v", status, resp)
+	}
+}
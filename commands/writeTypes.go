@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/StackExchange/dnscontrol/pkg/js"
+	"github.com/urfave/cli"
+)
+
+var _ = cmd(catUtils, func() *cli.Command {
+	var args WriteTypesArgs
+	return &cli.Command{
+		Name:  "write-types",
+		Usage: "Write the TypeScript declarations of the dnsconfig.js functions, for editor completion",
+		Action: func(c *cli.Context) error {
+			return exit(WriteTypes(args))
+		},
+		Flags: args.flags(),
+	}
+}())
+
+// WriteTypesArgs contains all data/flags needed to run write-types, independently of CLI.
+type WriteTypesArgs struct {
+	DTSFile string
+	DevMode bool
+}
+
+func (args *WriteTypesArgs) flags() []cli.Flag {
+	return []cli.Flag{
+		cli.StringFlag{
+			Name:        "dts-file",
+			Destination: &args.DTSFile,
+			Value:       "types-dnscontrol.d.ts",
+			Usage:       "File to write the declarations to",
+		},
+		cli.BoolFlag{
+			Name:        "dev",
+			Destination: &args.DevMode,
+			Usage:       "Use the declarations from disk instead of embedded copy",
+		},
+	}
+}
+
+// WriteTypes writes the TypeScript declarations of the functions of dnsconfig.js.
+func WriteTypes(args WriteTypesArgs) error {
+	if err := ioutil.WriteFile(args.DTSFile, []byte(js.GetTypes(args.DevMode)), 0644); err != nil {
+		return err
+	}
+	fmt.Printf("Wrote %s. Add this line to the top of dnsconfig.js:\n", args.DTSFile)
+	fmt.Printf("/// <reference path=\"%s\" />\n", args.DTSFile)
+	return nil
+}
@@ -32,10 +32,13 @@ func getProvider(t *testing.T) (providers.DNSServiceProvider, string, map[int]bo
 		t.Log("No provider specified with -provider")
 		return nil, "", nil
 	}
-	jsons, err := config.LoadProviderConfigs("providers.json")
+	jsons, err := config.ReadProviderConfigs("providers.json", "")
 	if err != nil {
 		t.Fatalf("Error loading provider configs: %s", err)
 	}
+	if err := config.ResolveReferences(jsons, *providerToRun); err != nil {
+		t.Fatalf("Error loading provider configs: %s", err)
+	}
 	fails := map[int]bool{}
 	for name, cfg := range jsons {
 		if *providerToRun != name {
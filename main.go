@@ -11,7 +11,7 @@ import (
 	_ "github.com/StackExchange/dnscontrol/providers/_all"
 )
 
-//go:generate go run build/generate/generate.go build/generate/featureMatrix.go
+//go:generate go run build/generate/generate.go build/generate/featureMatrix.go build/generate/types.go
 
 func main() {
 	log.SetFlags(log.LstdFlags | log.Lshortfile)
@@ -13,14 +13,17 @@ type DNSConfig struct {
 	Registrars         []*RegistrarConfig            `json:"registrars"`
 	DNSProviders       []*DNSProviderConfig          `json:"dns_providers"`
 	Domains            []*DomainConfig               `json:"domains"`
+	CLIVars            map[string]string             `json:"cli_vars,omitempty"` // --var and --var-file values given to dnsconfig.js
+	Env                map[string]string             `json:"env,omitempty"`      // environment variables read by ENV()
 	RegistrarsByName   map[string]*RegistrarConfig   `json:"-"`
 	DNSProvidersByName map[string]*DNSProviderConfig `json:"-"`
 }
 
 // FindDomain returns the *DomainConfig for domain query in config.
+// query is matched against UniqueName, so "example.com!view" finds a view.
 func (config *DNSConfig) FindDomain(query string) *DomainConfig {
 	for _, b := range config.Domains {
-		if b.Name == query {
+		if b.UniqueName() == query {
 			return b
 		}
 	}
@@ -63,16 +66,25 @@ func StringsToNameservers(nss []string) []*Nameserver {
 type Correction struct {
 	F   func() error `json:"-"`
 	Msg string
+	// Before and After optionally list the records the correction replaces and creates.
+	// They are informational (for example, for the audit log); providers should fill them in when they can,
+	// for example with diff.Changeset.Records. If both are nil, the records are unknown.
+	Before []*RecordConfig `json:"before,omitempty"`
+	After  []*RecordConfig `json:"after,omitempty"`
 }
 
 // DomainContainingFQDN finds the best domain from the dns config for the given record fqdn.
 // It will chose the domain whose name is the longest suffix match for the fqdn.
+// If there are several views of that domain, the one without a view is preferred.
 func (config *DNSConfig) DomainContainingFQDN(fqdn string) *DomainConfig {
 	fqdn = strings.TrimSuffix(fqdn, ".")
 	longestLength := 0
 	var d *DomainConfig
 	for _, dom := range config.Domains {
-		if (dom.Name == fqdn || strings.HasSuffix(fqdn, "."+dom.Name)) && len(dom.Name) > longestLength {
+		if dom.Name != fqdn && !strings.HasSuffix(fqdn, "."+dom.Name) {
+			continue
+		}
+		if len(dom.Name) > longestLength || (len(dom.Name) == longestLength && d.View != "" && dom.View == "") {
 			longestLength = len(dom.Name)
 			d = dom
 		}
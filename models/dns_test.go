@@ -88,3 +88,22 @@ func TestDowncase(t *testing.T) {
 		t.Errorf("%v: target1 expected (%v) got (%v)\n", dc.Records, "targetmx", dc.Records[1].GetTargetField())
 	}
 }
+
+func TestViews(t *testing.T) {
+	internal := &DomainConfig{Name: "example.com", View: "internal"}
+	external := &DomainConfig{Name: "example.com"}
+	config := &DNSConfig{Domains: []*DomainConfig{internal, external}}
+
+	if got := internal.UniqueName(); got != "example.com!internal" {
+		t.Errorf("UniqueName: expected example.com!internal got %s", got)
+	}
+	if got := config.FindDomain("example.com"); got != external {
+		t.Errorf("FindDomain(example.com): expected the domain without a view, got %v", got)
+	}
+	if got := config.FindDomain("example.com!internal"); got != internal {
+		t.Errorf("FindDomain(example.com!internal): expected the internal view, got %v", got)
+	}
+	if got := config.DomainContainingFQDN("www.example.com"); got != external {
+		t.Errorf("DomainContainingFQDN: expected the domain without a view, got %v", got)
+	}
+}
@@ -8,7 +8,8 @@ import (
 
 // DomainConfig describes a DNS domain (tecnically a  DNS zone).
 type DomainConfig struct {
-	Name             string         `json:"name"` // NO trailing "."
+	Name             string         `json:"name"`           // NO trailing "."
+	View             string         `json:"view,omitempty"` // D("example.com!view"): one of several record sets for Name
 	RegistrarName    string         `json:"registrar"`
 	DNSProviderNames map[string]int `json:"dnsProviders"`
 
@@ -17,6 +18,7 @@ type DomainConfig struct {
 	Nameservers   []*Nameserver     `json:"nameservers,omitempty"`
 	KeepUnknown   bool              `json:"keepunknown,omitempty"`
 	IgnoredLabels []string          `json:"ignored_labels,omitempty"`
+	Tags          []string          `json:"tags,omitempty"`
 	//DNSSEC        bool              `json:"dnssec,omitempty"`
 
 	// These fields contain instantiated provider instances once everything is linked up.
@@ -27,6 +29,15 @@ type DomainConfig struct {
 	DNSProviderInstances []*DNSProviderInstance `json:"-"`
 }
 
+// UniqueName returns Name, or "Name!View" if the domain is a view.
+// Providers manage the zone Name; UniqueName tells views of it apart.
+func (dc *DomainConfig) UniqueName() string {
+	if dc.View == "" {
+		return dc.Name
+	}
+	return dc.Name + "!" + dc.View
+}
+
 // Copy returns a deep copy of the DomainConfig.
 func (dc *DomainConfig) Copy() (*DomainConfig, error) {
 	newDc := &DomainConfig{}
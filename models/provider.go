@@ -1,5 +1,12 @@
 package models
 
+import (
+	"strings"
+
+	"github.com/gobwas/glob"
+	"github.com/pkg/errors"
+)
+
 // DNSProvider is an interface for DNS Provider plug-ins.
 type DNSProvider interface {
 	GetNameservers(domain string) ([]*Nameserver, error)
@@ -16,6 +23,44 @@ type ProviderBase struct {
 	Name         string
 	IsDefault    bool
 	ProviderType string
+	// AllowedDomains restricts the domains the provider's credentials may be used for (globs). Empty means all.
+	AllowedDomains []string
+	// ReadOnly credentials may be used to preview, but not to push.
+	ReadOnly bool
+}
+
+// AllowsDomain reports whether the provider's credentials may be used for domain.
+// Invalid patterns never match.
+func (p *ProviderBase) AllowsDomain(domain string) bool {
+	if len(p.AllowedDomains) == 0 {
+		return true
+	}
+	for _, pattern := range p.AllowedDomains {
+		g, err := glob.Compile(strings.ToLower(pattern), '.')
+		if err == nil && g.Match(strings.ToLower(domain)) {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckAllowed returns an error if the provider's credentials may not be used for domain.
+func (p *ProviderBase) CheckAllowed(domain string) error {
+	if !p.AllowsDomain(domain) {
+		return errors.Errorf("credentials for %s are not allowed to manage %s (see _allowed_domains)", p.Name, domain)
+	}
+	return nil
+}
+
+// CheckWritable returns an error if the provider's credentials may not be used to change domain.
+func (p *ProviderBase) CheckWritable(domain string) error {
+	if err := p.CheckAllowed(domain); err != nil {
+		return err
+	}
+	if p.ReadOnly {
+		return errors.Errorf("credentials for %s are read-only (see _read_only); not pushing", p.Name)
+	}
+	return nil
 }
 
 // RegistrarInstance is a single registrar.
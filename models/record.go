@@ -177,6 +177,12 @@ func (rc *RecordConfig) GetLabelFQDN() string {
 	return rc.NameFQDN
 }
 
+// SourceLocation returns the "file:line" of the dnsconfig.js (or require()d)
+// file that defined this RecordConfig, or "" if it is not known.
+func (rc *RecordConfig) SourceLocation() string {
+	return rc.Metadata["source_location"]
+}
+
 // ToDiffable returns a string that is comparable by a differ.
 // extraMaps: a list of maps that should be included in the comparison.
 func (rc *RecordConfig) ToDiffable(extraMaps ...map[string]string) string {
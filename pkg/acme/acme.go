@@ -199,6 +199,9 @@ func dnsNamesEqual(a []string, b []string) bool {
 func (c *certManager) Present(domain, token, keyAuth string) (e error) {
 	d := c.cfg.DomainContainingFQDN(domain)
 	name := d.Name
+	if err := checkWritable(d); err != nil {
+		return err
+	}
 	if seen := c.domains[name]; seen != nil {
 		// we've already pre-processed this domain, just need to add to it.
 		d = seen
@@ -253,6 +256,20 @@ func (c *certManager) ensureNoPendingCorrections(d *models.DomainConfig) error {
 	return nil
 }
 
+// checkWritable returns an error if the credentials of a DNS provider used to fill challenges
+// may not change d (see _allowed_domains and _read_only).
+func checkWritable(d *models.DomainConfig) error {
+	for _, p := range d.DNSProviderInstances {
+		if IgnoredProviders[p.Name] {
+			continue
+		}
+		if err := p.CheckWritable(d.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // IgnoredProviders is a lit of provider names that should not be used to fill challenges.
 var IgnoredProviders = map[string]bool{}
 
@@ -0,0 +1,40 @@
+package acme
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/models"
+)
+
+// refusingProvider fails the test if it is used.
+type refusingProvider struct {
+	t *testing.T
+}
+
+func (p refusingProvider) GetNameservers(string) ([]*models.Nameserver, error) {
+	p.t.Error("provider was used")
+	return nil, nil
+}
+
+func (p refusingProvider) GetDomainCorrections(dc *models.DomainConfig) ([]*models.Correction, error) {
+	p.t.Error("provider was used")
+	return nil, nil
+}
+
+func TestPresentChecksCredentials(t *testing.T) {
+	for _, base := range []models.ProviderBase{
+		{Name: "dsp", ReadOnly: true},
+		{Name: "dsp", AllowedDomains: []string{"example.net"}},
+	} {
+		cfg := &models.DNSConfig{Domains: []*models.DomainConfig{{
+			Name:                 "example.com",
+			DNSProviderInstances: []*models.DNSProviderInstance{{ProviderBase: base, Driver: refusingProvider{t}}},
+		}}}
+		c := &certManager{cfg: cfg, domains: map[string]*models.DomainConfig{}}
+		err := c.Present("www.example.com", "token", "keyAuth")
+		if err == nil || !strings.Contains(err.Error(), "credentials for dsp") {
+			t.Errorf("%+v: expected the credentials to be refused, got %v", base, err)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+// Package audit maintains an append-only log of the corrections made by push.
+//
+// The log is a file of JSON lines, one Entry per correction, so it can be
+// processed with standard tools as well as searched with the audit command.
+package audit
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/user"
+	"sync"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/models"
+	"github.com/pkg/errors"
+)
+
+// Results recorded in Entry.Result.
+const (
+	ResultSuccess = "success"
+	ResultError   = "error"
+	ResultSkipped = "skipped"
+)
+
+// Entry records a single correction.
+type Entry struct {
+	Time       time.Time              `json:"time"`
+	User       string                 `json:"user"`
+	Host       string                 `json:"host"`
+	ConfigHash string                 `json:"config_hash,omitempty"`
+	Domain     string                 `json:"domain"`
+	Provider   string                 `json:"provider"`
+	Correction string                 `json:"correction"`
+	Before     []*models.RecordConfig `json:"before,omitempty"`
+	After      []*models.RecordConfig `json:"after,omitempty"`
+	// RecordsUnknown is set if the provider does not report the records its corrections
+	// replace and create (see models.Correction). Before and After are empty then.
+	RecordsUnknown bool   `json:"records_unknown,omitempty"`
+	Result         string `json:"result"`
+	Error          string `json:"error,omitempty"`
+}
+
+// Log appends entries to an audit log file.
+type Log struct {
+	mu         sync.Mutex
+	f          *os.File
+	user, host string
+	configHash string
+}
+
+// Open opens (or creates) the audit log at path for appending.
+// configFile is hashed so that each entry identifies the configuration that produced it.
+func Open(path string, configFile string) (*Log, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0640)
+	if err != nil {
+		return nil, errors.Errorf("opening audit log: %s", err)
+	}
+	l := &Log{f: f}
+	l.host, _ = os.Hostname()
+	if u, err := user.Current(); err == nil {
+		l.user = u.Username
+	}
+	if configFile != "" {
+		if l.configHash, err = hashFile(configFile); err != nil {
+			f.Close()
+			return nil, err
+		}
+	}
+	return l, nil
+}
+
+func hashFile(path string) (string, error) {
+	dat, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", errors.Errorf("hashing config for audit log: %s", err)
+	}
+	sum := sha256.Sum256(dat)
+	return "sha256:" + hex.EncodeToString(sum[:]), nil
+}
+
+// Record appends an entry for a correction. If the correction was not run, it is recorded as skipped.
+func (l *Log) Record(domain, provider string, c *models.Correction, ran bool, err error) error {
+	e := &Entry{
+		Time:       time.Now().UTC(),
+		User:       l.user,
+		Host:       l.host,
+		ConfigHash: l.configHash,
+		Domain:     domain,
+		Provider:   provider,
+		Correction: c.Msg,
+		Before:     c.Before,
+		After:      c.After,
+		Result:     ResultSuccess,
+	}
+	e.RecordsUnknown = c.Before == nil && c.After == nil
+	if !ran {
+		e.Result = ResultSkipped
+	} else if err != nil {
+		e.Result = ResultError
+		e.Error = err.Error()
+	}
+	return l.Append(e)
+}
+
+// Append writes e to the log and syncs it to disk.
+func (l *Log) Append(e *Entry) error {
+	dat, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, err := l.f.Write(append(dat, '\n')); err != nil {
+		return errors.Errorf("writing audit log: %s", err)
+	}
+	return l.f.Sync()
+}
+
+// Close closes the log file.
+func (l *Log) Close() error {
+	return l.f.Close()
+}
+
+// Filter selects entries when searching. Zero values match everything.
+type Filter struct {
+	Domain string
+	Since  time.Time
+	Until  time.Time
+}
+
+func (f Filter) match(e *Entry) bool {
+	if f.Domain != "" && e.Domain != f.Domain {
+		return false
+	}
+	if !f.Since.IsZero() && e.Time.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && !e.Time.Before(f.Until) {
+		return false
+	}
+	return true
+}
+
+// Search returns the entries in r that match f, in log order.
+func Search(r io.Reader, f Filter) ([]*Entry, error) {
+	var entries []*Entry
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		e := &Entry{}
+		if err := json.Unmarshal(scanner.Bytes(), e); err != nil {
+			return nil, errors.Errorf("audit log line %d: %s", line, err)
+		}
+		if f.match(e) {
+			entries = append(entries, e)
+		}
+	}
+	return entries, scanner.Err()
+}
@@ -0,0 +1,76 @@
+package audit
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/models"
+	"github.com/pkg/errors"
+)
+
+func TestLogAndSearch(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dnscontrol-audit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	cfgFile := filepath.Join(dir, "dnsconfig.js")
+	if err := ioutil.WriteFile(cfgFile, []byte(`D("example.com","reg")`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "audit.log")
+
+	l, err := Open(path, cfgFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec := &models.RecordConfig{Type: "A", Name: "www", Target: "1.2.3.4"}
+	l.Record("example.com", "bind", &models.Correction{Msg: "CREATE A www", After: []*models.RecordConfig{rec}}, true, nil)
+	l.Record("example.net", "bind", &models.Correction{Msg: "DELETE A www"}, true, errors.New("denied"))
+	l.Close()
+
+	// A second run appends to the same log.
+	l, err = Open(path, cfgFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l.Record("example.com", "bind", &models.Correction{Msg: "MODIFY A www"}, false, nil)
+	l.Close()
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	entries, err := Search(f, Filter{Domain: "example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+	if entries[0].Result != ResultSuccess || len(entries[0].After) != 1 || entries[0].After[0].Target != "1.2.3.4" {
+		t.Errorf("unexpected first entry: %+v", entries[0])
+	}
+	if entries[0].RecordsUnknown || !entries[1].RecordsUnknown {
+		t.Errorf("expected only the second entry's records to be unknown: %+v, %+v", entries[0], entries[1])
+	}
+	if entries[1].Result != ResultSkipped {
+		t.Errorf("expected second entry to be skipped, got %s", entries[1].Result)
+	}
+	if entries[0].ConfigHash == "" || entries[0].ConfigHash != entries[1].ConfigHash {
+		t.Errorf("expected matching config hashes, got %q and %q", entries[0].ConfigHash, entries[1].ConfigHash)
+	}
+
+	f.Seek(0, 0)
+	entries, err = Search(f, Filter{Until: time.Now().Add(-time.Hour)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("expected no entries before an hour ago, got %d", len(entries))
+	}
+}
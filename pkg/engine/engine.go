@@ -0,0 +1,489 @@
+// Package engine implements the preview/push pipeline independently of the CLI.
+//
+// Callers hand it a fully validated DNSConfig whose provider drivers have been
+// initialized (see commands.InitializeProviders) and receive a stream of Results
+// as each domain and provider is processed. The dnscontrol CLI is a thin client
+// on top of this package; other programs can embed it the same way.
+package engine
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/models"
+	"github.com/StackExchange/dnscontrol/pkg/lock"
+	"github.com/StackExchange/dnscontrol/pkg/nameservers"
+	"github.com/pkg/errors"
+)
+
+// ResultType identifies what a Result describes.
+type ResultType int
+
+const (
+	// DomainStarted is sent before any provider of a domain is processed.
+	DomainStarted ResultType = iota
+	// ProviderStarted is sent before corrections are computed for a DNS provider or registrar.
+	// Skipped is true if the provider was filtered out and will not be run.
+	ProviderStarted
+	// ProviderCompleted carries the corrections computed for a provider, or the error computing them.
+	// If the provider's credentials are read-only, a push sets both: the corrections that were not run, and the reason.
+	ProviderCompleted
+	// CorrectionPlanned is sent for each correction before it is (possibly) run.
+	CorrectionPlanned
+	// CorrectionCompleted is sent after each correction. Ran is true if it was executed.
+	CorrectionCompleted
+	// DomainFailed is sent when a domain cannot be processed, for example because it is locked.
+	// Err holds the reason. Processing continues with the next domain.
+	DomainFailed
+	// Warning carries a non-fatal message in Message.
+	Warning
+	// Aborted is sent when processing cannot continue. Err holds the reason. It is always the last result.
+	Aborted
+	// DomainCompleted is sent after all providers of a domain were processed, or after DomainFailed.
+	DomainCompleted
+	// PlanComputed is sent with Options.PlanFirst once the corrections of every domain are computed,
+	// before any is run. The receiver must send exactly one value on Approve: true to run them all,
+	// false to abort.
+	PlanComputed
+)
+
+var resultTypeNames = map[ResultType]string{
+	DomainStarted:       "domain_started",
+	ProviderStarted:     "provider_started",
+	ProviderCompleted:   "provider_completed",
+	CorrectionPlanned:   "correction_planned",
+	CorrectionCompleted: "correction_completed",
+	DomainFailed:        "domain_failed",
+	Warning:             "warning",
+	Aborted:             "aborted",
+	DomainCompleted:     "domain_completed",
+	PlanComputed:        "plan_computed",
+}
+
+func (t ResultType) String() string {
+	return resultTypeNames[t]
+}
+
+// Result is a single event produced while previewing or pushing.
+type Result struct {
+	Type        ResultType
+	Domain      string
+	Provider    string
+	IsRegistrar bool
+	Skipped     bool
+
+	// Corrections is set on ProviderCompleted.
+	Corrections []*models.Correction
+	// Correction and Index are set on CorrectionPlanned and CorrectionCompleted.
+	Correction *models.Correction
+	Index      int
+	// Ran is set on CorrectionCompleted if the correction was executed.
+	Ran bool
+	// Attempts is the number of times the correction was run (see Options.Retries).
+	Attempts int
+
+	Message string
+	Err     error
+
+	// Approve is set on CorrectionPlanned when Options.Interactive is true, and on PlanComputed.
+	// The receiver must send exactly one value: true to run the correction (or the plan), false to skip it.
+	Approve chan<- bool `json:"-"`
+
+	done chan struct{}
+}
+
+// Done tells the engine that the receiver has finished handling the result.
+// It must be called for every result when Options.Synchronous is set, and is a no-op otherwise.
+func (r Result) Done() {
+	if r.done != nil {
+		close(r.done)
+	}
+}
+
+// Options controls which domains and providers are processed and whether corrections are run.
+type Options struct {
+	// Push runs corrections. If false, corrections are only reported.
+	Push bool
+	// Interactive asks the receiver to approve each correction before it is run. Only used with Push.
+	Interactive bool
+	// ShouldRunDomain filters domains. nil means all domains.
+	ShouldRunDomain func(dc *models.DomainConfig) bool
+	// ShouldRunProvider filters providers and registrars by name. nil means all providers.
+	ShouldRunProvider func(name string, dc *models.DomainConfig) bool
+	// PlanFirst computes the corrections of every selected domain and provider before running any,
+	// and asks the receiver to approve them all at once (see PlanComputed). The domains stay locked
+	// until the corrections ran, so exactly the approved corrections are run. Only used with Push;
+	// Interactive is ignored.
+	PlanFirst bool
+	// Locker is used to lock each domain before its corrections are computed. Only used with Push.
+	// nil means no locking.
+	Locker lock.Locker
+	// Synchronous waits for the receiver to call Result.Done before continuing. Use it when the
+	// receiver's output must stay in order with output printed by providers while they run.
+	Synchronous bool
+	// Retries is how many times a failed correction is retried. Each retry waits twice as long
+	// as the previous one, starting with RetryBackoff.
+	Retries      int
+	RetryBackoff time.Duration
+}
+
+// Preview computes the corrections for every selected domain and provider without running them.
+func Preview(ctx context.Context, cfg *models.DNSConfig, opts Options) (<-chan Result, error) {
+	opts.Push = false
+	return Run(ctx, cfg, opts)
+}
+
+// Push computes and runs the corrections for every selected domain and provider.
+func Push(ctx context.Context, cfg *models.DNSConfig, opts Options) (<-chan Result, error) {
+	opts.Push = true
+	return Run(ctx, cfg, opts)
+}
+
+// Run is the routine common to Preview and Push. The returned channel is closed once all
+// domains are processed, processing is aborted, or ctx is cancelled.
+func Run(ctx context.Context, cfg *models.DNSConfig, opts Options) (<-chan Result, error) {
+	if cfg == nil {
+		return nil, errors.Errorf("no configuration given")
+	}
+	for _, domain := range cfg.Domains {
+		if domain.RegistrarInstance == nil || domain.RegistrarInstance.Driver == nil {
+			return nil, errors.Errorf("registrar for %s has not been initialized", domain.UniqueName())
+		}
+		for _, p := range domain.DNSProviderInstances {
+			if p.Driver == nil {
+				return nil, errors.Errorf("DNS provider %s for %s has not been initialized", p.Name, domain.UniqueName())
+			}
+		}
+	}
+	if opts.ShouldRunDomain == nil {
+		opts.ShouldRunDomain = func(*models.DomainConfig) bool { return true }
+	}
+	if opts.ShouldRunProvider == nil {
+		opts.ShouldRunProvider = func(string, *models.DomainConfig) bool { return true }
+	}
+	r := &runner{ctx: ctx, opts: opts, results: make(chan Result), locks: map[string]lock.Lock{}}
+	go func() {
+		defer close(r.results)
+		if err := r.run(cfg); err != nil {
+			r.send(Result{Type: Aborted, Err: err})
+		}
+	}()
+	return r.results, nil
+}
+
+type runner struct {
+	ctx     context.Context
+	opts    Options
+	results chan Result
+	locks   map[string]lock.Lock
+}
+
+// runCorrectionsFunc runs, or collects, the corrections computed for a provider.
+type runCorrectionsFunc func(domain, provider string, isRegistrar bool, corrections []*models.Correction) error
+
+// plannedCorrections are the corrections computed for a provider with Options.PlanFirst.
+type plannedCorrections struct {
+	domain      string
+	provider    string
+	isRegistrar bool
+	corrections []*models.Correction
+}
+
+// errCancelled stops the runner without sending anything further.
+var errCancelled = errors.New("cancelled")
+
+// send delivers a result, or returns false if the context was cancelled first.
+func (r *runner) send(res Result) bool {
+	select {
+	case r.results <- res:
+		return true
+	case <-r.ctx.Done():
+		return false
+	}
+}
+
+func (r *runner) emit(res Result) error {
+	if r.opts.Synchronous {
+		res.done = make(chan struct{})
+	}
+	if r.ctx.Err() != nil || !r.send(res) {
+		return errCancelled
+	}
+	if res.done != nil {
+		select {
+		case <-res.done:
+		case <-r.ctx.Done():
+			return errCancelled
+		}
+	}
+	return nil
+}
+
+func (r *runner) run(cfg *models.DNSConfig) error {
+	err := r.runDomains(cfg)
+	if err == errCancelled {
+		return nil
+	}
+	return err
+}
+
+func (r *runner) runDomains(cfg *models.DNSConfig) error {
+	defer r.unlockAll()
+	if r.opts.Push && r.opts.PlanFirst {
+		return r.runPlanFirst(cfg)
+	}
+	for _, domain := range cfg.Domains {
+		if !r.opts.ShouldRunDomain(domain) {
+			continue
+		}
+		if err := r.emit(Result{Type: DomainStarted, Domain: domain.UniqueName()}); err != nil {
+			return err
+		}
+		locked, err := r.lockDomain(domain.UniqueName())
+		if err != nil {
+			return err
+		}
+		if locked {
+			err = r.runDomain(domain, r.runCorrections)
+			r.unlockAll()
+			if err != nil {
+				return err
+			}
+		}
+		if err := r.emit(Result{Type: DomainCompleted, Domain: domain.UniqueName()}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runPlanFirst computes the corrections of all domains, then runs them once the receiver approves.
+func (r *runner) runPlanFirst(cfg *models.DNSConfig) error {
+	var plan []*plannedCorrections
+	collect := func(domain, provider string, isRegistrar bool, corrections []*models.Correction) error {
+		plan = append(plan, &plannedCorrections{domain: domain, provider: provider, isRegistrar: isRegistrar, corrections: corrections})
+		return nil
+	}
+	var domains []string
+	for _, domain := range cfg.Domains {
+		if !r.opts.ShouldRunDomain(domain) {
+			continue
+		}
+		domains = append(domains, domain.UniqueName())
+		if err := r.emit(Result{Type: DomainStarted, Domain: domain.UniqueName()}); err != nil {
+			return err
+		}
+		locked, err := r.lockDomain(domain.UniqueName())
+		if err != nil {
+			return err
+		}
+		if locked {
+			if err := r.runDomain(domain, collect); err != nil {
+				return err
+			}
+		}
+	}
+
+	approve := make(chan bool, 1)
+	if err := r.emit(Result{Type: PlanComputed, Approve: approve}); err != nil {
+		return err
+	}
+	select {
+	case ok := <-approve:
+		if !ok {
+			return errors.Errorf("plan was not approved")
+		}
+	case <-r.ctx.Done():
+		return errCancelled
+	}
+	r.opts.Interactive = false
+	for _, p := range plan {
+		if err := r.runCorrections(p.domain, p.provider, p.isRegistrar, p.corrections); err != nil {
+			return err
+		}
+	}
+	for _, name := range domains {
+		if err := r.emit(Result{Type: DomainCompleted, Domain: name}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lockDomain locks a domain for a push. It returns false, after sending DomainFailed, if the
+// domain could not be locked.
+func (r *runner) lockDomain(name string) (bool, error) {
+	if !r.opts.Push || r.opts.Locker == nil {
+		return true, nil
+	}
+	lk, err := r.opts.Locker.Lock(name)
+	if err != nil {
+		return false, r.emit(Result{Type: DomainFailed, Domain: name, Err: err})
+	}
+	r.locks[name] = lk
+	return true, nil
+}
+
+// unlockAll releases the locks taken by lockDomain.
+func (r *runner) unlockAll() {
+	for name, lk := range r.locks {
+		if err := lk.Unlock(); err != nil {
+			r.emit(Result{Type: Warning, Domain: name, Message: fmt.Sprintf("Releasing lock: %s", err)})
+		}
+		delete(r.locks, name)
+	}
+}
+
+// runDomain computes the corrections of each provider of a domain and hands them to run.
+func (r *runner) runDomain(domain *models.DomainConfig, run runCorrectionsFunc) error {
+	// Work on a copy so that the caller's config can be run again (for example, preview then push).
+	domain, err := domain.Copy()
+	if err != nil {
+		return err
+	}
+	nsList, err := nameservers.DetermineNameservers(domain)
+	if err != nil {
+		return err
+	}
+	domain.Nameservers = nsList
+	nameservers.AddNSRecords(domain)
+	for _, provider := range domain.DNSProviderInstances {
+		dc, err := domain.Copy()
+		if err != nil {
+			return err
+		}
+		shouldrun := r.opts.ShouldRunProvider(provider.Name, dc)
+		if err := r.emit(Result{Type: ProviderStarted, Domain: domain.UniqueName(), Provider: provider.Name, Skipped: !shouldrun}); err != nil {
+			return err
+		}
+		if !shouldrun {
+			continue
+		}
+		var corrections []*models.Correction
+		err = provider.CheckAllowed(domain.Name)
+		if err == nil {
+			corrections, err = provider.Driver.GetDomainCorrections(dc)
+		}
+		if err == nil {
+			err = r.checkWritable(&provider.ProviderBase, domain.Name, corrections)
+		}
+		if err := r.emit(Result{Type: ProviderCompleted, Domain: domain.UniqueName(), Provider: provider.Name, Corrections: corrections, Err: err}); err != nil {
+			return err
+		}
+		if err != nil {
+			// Skip the remaining providers and the registrar of this domain.
+			return nil
+		}
+		if err := run(domain.UniqueName(), provider.Name, false, corrections); err != nil {
+			return err
+		}
+	}
+
+	name := domain.RegistrarName
+	shouldrun := r.opts.ShouldRunProvider(name, domain)
+	if err := r.emit(Result{Type: ProviderStarted, Domain: domain.UniqueName(), Provider: name, IsRegistrar: true, Skipped: !shouldrun}); err != nil {
+		return err
+	}
+	if !shouldrun {
+		return nil
+	}
+	if len(domain.Nameservers) == 0 && domain.Metadata["no_ns"] != "true" {
+		return r.emit(Result{Type: Warning, Domain: domain.UniqueName(), Provider: name, IsRegistrar: true,
+			Message: "No nameservers declared; skipping registrar. Add {no_ns:'true'} to force."})
+	}
+	dc, err := domain.Copy()
+	if err != nil {
+		return err
+	}
+	var corrections []*models.Correction
+	err = domain.RegistrarInstance.CheckAllowed(domain.Name)
+	if err == nil {
+		corrections, err = domain.RegistrarInstance.Driver.GetRegistrarCorrections(dc)
+	}
+	if err == nil {
+		err = r.checkWritable(&domain.RegistrarInstance.ProviderBase, domain.Name, corrections)
+	}
+	if err := r.emit(Result{Type: ProviderCompleted, Domain: domain.UniqueName(), Provider: name, IsRegistrar: true, Corrections: corrections, Err: err}); err != nil {
+		return err
+	}
+	if err != nil {
+		return nil
+	}
+	return run(domain.UniqueName(), name, true, corrections)
+}
+
+// checkWritable refuses to push corrections with read-only credentials.
+func (r *runner) checkWritable(p *models.ProviderBase, domain string, corrections []*models.Correction) error {
+	if r.opts.Push && len(corrections) > 0 {
+		return p.CheckWritable(domain)
+	}
+	return nil
+}
+
+func (r *runner) runCorrections(domain, provider string, isRegistrar bool, corrections []*models.Correction) error {
+	for i, correction := range corrections {
+		res := Result{Domain: domain, Provider: provider, IsRegistrar: isRegistrar, Correction: correction, Index: i}
+
+		planned := res
+		planned.Type = CorrectionPlanned
+		var approve chan bool
+		if r.opts.Push && r.opts.Interactive {
+			approve = make(chan bool, 1)
+			planned.Approve = approve
+		}
+		if err := r.emit(planned); err != nil {
+			return err
+		}
+
+		run := r.opts.Push
+		if approve != nil {
+			select {
+			case run = <-approve:
+			case <-r.ctx.Done():
+				return errCancelled
+			}
+		}
+		if r.ctx.Err() != nil {
+			return errCancelled
+		}
+
+		res.Type = CorrectionCompleted
+		if run {
+			res.Ran = true
+			res.Attempts, res.Err = r.runCorrection(res, correction)
+			if res.Err == errCancelled {
+				return errCancelled
+			}
+		} else {
+			res.Skipped = r.opts.Push
+		}
+		if err := r.emit(res); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runCorrection runs a correction, retrying it as configured in Options. It returns
+// errCancelled if the context is cancelled while waiting to retry.
+func (r *runner) runCorrection(res Result, correction *models.Correction) (int, error) {
+	backoff := r.opts.RetryBackoff
+	err := correction.F()
+	attempts := 1
+	for ; err != nil && attempts <= r.opts.Retries; attempts++ {
+		res.Type = Warning
+		res.Message = fmt.Sprintf("Correction failed (%s); retrying in %s", err, backoff)
+		if err := r.emit(res); err != nil {
+			return attempts, err
+		}
+		select {
+		case <-time.After(backoff):
+		case <-r.ctx.Done():
+			return attempts, errCancelled
+		}
+		backoff *= 2
+		err = correction.F()
+	}
+	return attempts, err
+}
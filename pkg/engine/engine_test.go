@@ -0,0 +1,307 @@
+package engine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/models"
+	"github.com/StackExchange/dnscontrol/pkg/lock"
+	"github.com/pkg/errors"
+)
+
+type fakeProvider struct {
+	corrections []*models.Correction
+	err         error
+}
+
+func (f *fakeProvider) GetNameservers(string) ([]*models.Nameserver, error) {
+	return nil, nil
+}
+
+func (f *fakeProvider) GetDomainCorrections(dc *models.DomainConfig) ([]*models.Correction, error) {
+	return f.corrections, f.err
+}
+
+func (f *fakeProvider) GetRegistrarCorrections(dc *models.DomainConfig) ([]*models.Correction, error) {
+	return nil, nil
+}
+
+func makeConfig(p *fakeProvider) *models.DNSConfig {
+	return &models.DNSConfig{
+		Domains: []*models.DomainConfig{{
+			Name:              "example.com",
+			RegistrarName:     "reg",
+			Metadata:          map[string]string{},
+			RegistrarInstance: &models.RegistrarInstance{ProviderBase: models.ProviderBase{Name: "reg"}, Driver: p},
+			DNSProviderInstances: []*models.DNSProviderInstance{
+				{ProviderBase: models.ProviderBase{Name: "dsp"}, Driver: p},
+			},
+		}},
+	}
+}
+
+func collect(t *testing.T, ch <-chan Result, err error) []Result {
+	if err != nil {
+		t.Fatal(err)
+	}
+	var results []Result
+	for r := range ch {
+		if r.Approve != nil {
+			r.Approve <- r.Index == 0
+		}
+		results = append(results, r)
+	}
+	return results
+}
+
+func resultTypes(results []Result) []ResultType {
+	types := []ResultType{}
+	for _, r := range results {
+		types = append(types, r.Type)
+	}
+	return types
+}
+
+func TestPreviewDoesNotRun(t *testing.T) {
+	ran := false
+	p := &fakeProvider{corrections: []*models.Correction{{Msg: "one", F: func() error { ran = true; return nil }}}}
+	ch, err := Preview(context.Background(), makeConfig(p), Options{})
+	results := collect(t, ch, err)
+	if ran {
+		t.Fatal("preview ran a correction")
+	}
+	expected := []ResultType{DomainStarted, ProviderStarted, ProviderCompleted, CorrectionPlanned, CorrectionCompleted, ProviderStarted, Warning, DomainCompleted}
+	if got := resultTypes(results); len(got) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	} else {
+		for i := range got {
+			if got[i] != expected[i] {
+				t.Fatalf("expected %v, got %v", expected, got)
+			}
+		}
+	}
+}
+
+func TestPushRunsAndReportsErrors(t *testing.T) {
+	p := &fakeProvider{corrections: []*models.Correction{
+		{Msg: "ok", F: func() error { return nil }},
+		{Msg: "bad", F: func() error { return errors.New("boom") }},
+	}}
+	ch, err := Push(context.Background(), makeConfig(p), Options{})
+	results := collect(t, ch, err)
+	var completed []Result
+	for _, r := range results {
+		if r.Type == CorrectionCompleted {
+			completed = append(completed, r)
+		}
+	}
+	if len(completed) != 2 {
+		t.Fatalf("expected 2 completed corrections, got %d", len(completed))
+	}
+	if !completed[0].Ran || completed[0].Err != nil {
+		t.Errorf("expected first correction to succeed: %+v", completed[0])
+	}
+	if !completed[1].Ran || completed[1].Err == nil {
+		t.Errorf("expected second correction to fail: %+v", completed[1])
+	}
+}
+
+func TestInteractiveApproval(t *testing.T) {
+	count := 0
+	f := func() error { count++; return nil }
+	p := &fakeProvider{corrections: []*models.Correction{{Msg: "a", F: f}, {Msg: "b", F: f}}}
+	ch, err := Push(context.Background(), makeConfig(p), Options{Interactive: true})
+	results := collect(t, ch, err)
+	if count != 1 {
+		t.Fatalf("expected 1 approved correction to run, got %d", count)
+	}
+	last := Result{}
+	for _, r := range results {
+		if r.Type == CorrectionCompleted {
+			last = r
+		}
+	}
+	if !last.Skipped || last.Ran {
+		t.Errorf("expected declined correction to be skipped: %+v", last)
+	}
+}
+
+func TestProviderErrorSkipsRegistrar(t *testing.T) {
+	p := &fakeProvider{err: errors.New("no auth")}
+	ch, err := Preview(context.Background(), makeConfig(p), Options{})
+	results := collect(t, ch, err)
+	last := results[len(results)-2]
+	if last.Type != ProviderCompleted || last.Err == nil {
+		t.Fatalf("expected provider error to complete the domain, got %+v", last)
+	}
+}
+
+func TestCancel(t *testing.T) {
+	p := &fakeProvider{}
+	ctx, cancel := context.WithCancel(context.Background())
+	ch, err := Preview(ctx, makeConfig(p), Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-ch
+	cancel()
+	for range ch {
+	}
+}
+
+func TestUninitialized(t *testing.T) {
+	cfg := makeConfig(&fakeProvider{})
+	cfg.Domains[0].DNSProviderInstances[0].Driver = nil
+	if _, err := Preview(context.Background(), cfg, Options{}); err == nil {
+		t.Fatal("expected error for uninitialized provider")
+	}
+}
+
+type fakeLocker struct {
+	locked map[string]bool
+}
+
+func (f *fakeLocker) Lock(zone string) (lock.Lock, error) {
+	if f.locked[zone] {
+		return nil, errors.Errorf("%s is locked", zone)
+	}
+	f.locked[zone] = true
+	return f, nil
+}
+
+func (f *fakeLocker) Unlock() error {
+	f.locked = map[string]bool{}
+	return nil
+}
+
+func TestLockedDomainFails(t *testing.T) {
+	ran := false
+	p := &fakeProvider{corrections: []*models.Correction{{Msg: "one", F: func() error { ran = true; return nil }}}}
+	locker := &fakeLocker{locked: map[string]bool{"example.com": true}}
+	ch, err := Push(context.Background(), makeConfig(p), Options{Locker: locker})
+	results := collect(t, ch, err)
+	if ran {
+		t.Fatal("correction ran on a locked domain")
+	}
+	if len(results) != 3 || results[1].Type != DomainFailed {
+		t.Fatalf("expected domain to fail, got %v", resultTypes(results))
+	}
+
+	locker.locked = map[string]bool{}
+	ch, err = Push(context.Background(), makeConfig(p), Options{Locker: locker})
+	collect(t, ch, err)
+	if !ran {
+		t.Fatal("correction did not run on an unlocked domain")
+	}
+	if len(locker.locked) != 0 {
+		t.Fatal("lock was not released")
+	}
+}
+
+func TestSynchronous(t *testing.T) {
+	handled := 0
+	p := &fakeProvider{corrections: []*models.Correction{{Msg: "one", F: func() error {
+		if handled != 4 {
+			t.Errorf("correction ran before the planned result was handled (%d handled)", handled)
+		}
+		return nil
+	}}}}
+	ch, err := Push(context.Background(), makeConfig(p), Options{Synchronous: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for r := range ch {
+		handled++
+		r.Done()
+	}
+}
+
+func TestAllowedDomains(t *testing.T) {
+	p := &fakeProvider{corrections: []*models.Correction{{Msg: "one", F: func() error { return nil }}}}
+	cfg := makeConfig(p)
+	cfg.Domains[0].DNSProviderInstances[0].AllowedDomains = []string{"*.example.com"}
+	ch, err := Preview(context.Background(), cfg, Options{})
+	results := collect(t, ch, err)
+	last := results[len(results)-2]
+	if last.Type != ProviderCompleted || last.Provider != "dsp" || last.Err == nil {
+		t.Fatalf("expected provider to be refused, got %+v", last)
+	}
+
+	cfg.Domains[0].DNSProviderInstances[0].AllowedDomains = []string{"example.*"}
+	ch, err = Preview(context.Background(), cfg, Options{})
+	for _, r := range collect(t, ch, err) {
+		if r.Err != nil {
+			t.Fatalf("unexpected error %s", r.Err)
+		}
+	}
+}
+
+func TestReadOnly(t *testing.T) {
+	ran := false
+	p := &fakeProvider{corrections: []*models.Correction{{Msg: "one", F: func() error { ran = true; return nil }}}}
+	cfg := makeConfig(p)
+	cfg.Domains[0].DNSProviderInstances[0].ReadOnly = true
+
+	ch, err := Preview(context.Background(), cfg, Options{})
+	for _, r := range collect(t, ch, err) {
+		if r.Err != nil {
+			t.Fatalf("preview with read-only credentials failed: %s", r.Err)
+		}
+	}
+
+	ch, err = Push(context.Background(), cfg, Options{})
+	results := collect(t, ch, err)
+	if ran {
+		t.Fatal("correction ran with read-only credentials")
+	}
+	refused := false
+	for _, r := range results {
+		if r.Type == ProviderCompleted && r.Provider == "dsp" && r.Err != nil && len(r.Corrections) == 1 {
+			refused = true
+		}
+	}
+	if !refused {
+		t.Fatalf("expected the push to be refused, got %v", resultTypes(results))
+	}
+}
+
+func TestPlanFirst(t *testing.T) {
+	locker := &fakeLocker{locked: map[string]bool{}}
+	ran := 0
+	p := &fakeProvider{corrections: []*models.Correction{{Msg: "one", F: func() error {
+		if !locker.locked["example.com"] {
+			t.Error("correction ran without holding the lock")
+		}
+		ran++
+		return nil
+	}}}}
+	for _, approve := range []bool{false, true} {
+		ch, err := Push(context.Background(), makeConfig(p), Options{PlanFirst: true, Locker: locker})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var types []ResultType
+		planned := 0
+		for r := range ch {
+			types = append(types, r.Type)
+			switch r.Type {
+			case ProviderCompleted:
+				planned += len(r.Corrections)
+			case PlanComputed:
+				if ran != 0 || planned != 1 {
+					t.Fatalf("expected 1 correction planned and none run before approval, got %d planned, %d run", planned, ran)
+				}
+				r.Approve <- approve
+			}
+		}
+		if last := types[len(types)-1]; approve && last != DomainCompleted || !approve && last != Aborted {
+			t.Errorf("approve=%v: unexpected results %v", approve, types)
+		}
+		if len(locker.locked) != 0 {
+			t.Errorf("approve=%v: lock was not released", approve)
+		}
+	}
+	if ran != 1 {
+		t.Fatalf("expected the correction to run once, after approval; ran %d times", ran)
+	}
+}
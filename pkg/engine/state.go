@@ -0,0 +1,169 @@
+package engine
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// State records the outcome of a push, so that a failed push can be resumed.
+// Call Expect with the domains about to be pushed, feed it every Result with Record, then Save it.
+type State struct {
+	Time    time.Time               `json:"time"`
+	Aborted string                  `json:"aborted,omitempty"`
+	Domains map[string]*DomainState `json:"domains"`
+}
+
+// DomainState records the outcome of a push for one domain.
+type DomainState struct {
+	// Error is set if the domain could not be processed at all (see DomainFailed).
+	Error string `json:"error,omitempty"`
+	// Pending is set if the push stopped before the domain was completed, for example
+	// because it was aborted or cancelled.
+	Pending   bool                      `json:"pending,omitempty"`
+	Providers map[string]*ProviderState `json:"providers,omitempty"`
+}
+
+// ProviderState records the outcome of a push for one DNS provider or registrar of a domain.
+type ProviderState struct {
+	// Error is set if the corrections could not be computed.
+	Error     string              `json:"error,omitempty"`
+	Succeeded []string            `json:"succeeded,omitempty"`
+	Failed    []*FailedCorrection `json:"failed,omitempty"`
+}
+
+// FailedCorrection is a correction that returned an error.
+type FailedCorrection struct {
+	Msg      string `json:"msg"`
+	Error    string `json:"error"`
+	Attempts int    `json:"attempts"`
+}
+
+// NewState creates an empty State.
+func NewState() *State {
+	return &State{Time: time.Now().UTC(), Domains: map[string]*DomainState{}}
+}
+
+// LoadState reads a State written by Save. If there is no file at path, the State is empty.
+func LoadState(path string) (*State, error) {
+	dat, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return NewState(), nil
+	}
+	if err != nil {
+		return nil, errors.Errorf("reading push state: %s", err)
+	}
+	s := NewState()
+	if err := json.Unmarshal(dat, s); err != nil {
+		return nil, errors.Errorf("reading push state %s: %s", path, err)
+	}
+	return s, nil
+}
+
+// Save writes the state to path.
+func (s *State) Save(path string) error {
+	dat, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, dat, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func (s *State) domain(name string) *DomainState {
+	d := s.Domains[name]
+	if d == nil {
+		d = &DomainState{Providers: map[string]*ProviderState{}}
+		s.Domains[name] = d
+	}
+	return d
+}
+
+func (s *State) provider(domain, name string) *ProviderState {
+	d := s.domain(domain)
+	p := d.Providers[name]
+	if p == nil {
+		p = &ProviderState{}
+		d.Providers[name] = p
+	}
+	return p
+}
+
+// Expect records the domains that are about to be pushed. They stay pending until the
+// engine reports them completed, so that a push which stops early can resume them all.
+func (s *State) Expect(domains ...string) {
+	for _, name := range domains {
+		s.domain(name).Pending = true
+	}
+}
+
+// Record updates the state with a result.
+func (s *State) Record(res Result) {
+	switch res.Type {
+	case DomainStarted:
+		s.domain(res.Domain).Pending = true
+	case DomainCompleted:
+		s.domain(res.Domain).Pending = false
+	case DomainFailed:
+		s.domain(res.Domain).Error = res.Err.Error()
+	case ProviderCompleted:
+		if res.Err != nil {
+			s.provider(res.Domain, res.Provider).Error = res.Err.Error()
+		}
+	case CorrectionCompleted:
+		if !res.Ran {
+			return
+		}
+		p := s.provider(res.Domain, res.Provider)
+		if res.Err == nil {
+			p.Succeeded = append(p.Succeeded, res.Correction.Msg)
+		} else {
+			p.Failed = append(p.Failed, &FailedCorrection{Msg: res.Correction.Msg, Error: res.Err.Error(), Attempts: res.Attempts})
+		}
+	case Aborted:
+		s.Aborted = res.Err.Error()
+	}
+}
+
+// Merge adds the domains that failed in previous and are not in s. A push of some of the domains
+// then keeps the failures of the others, so that they can still be resumed.
+func (s *State) Merge(previous *State) {
+	for _, name := range previous.FailedDomains() {
+		if s.Domains[name] == nil {
+			s.Domains[name] = previous.Domains[name]
+		}
+	}
+}
+
+// Failed reports whether anything went wrong for the domain, or it was not completed.
+func (d *DomainState) Failed() bool {
+	if d.Error != "" || d.Pending {
+		return true
+	}
+	for _, p := range d.Providers {
+		if p.Error != "" || len(p.Failed) != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// FailedDomains returns the names of the domains that need to be pushed again, sorted:
+// those that failed and those that were not completed.
+func (s *State) FailedDomains() []string {
+	names := []string{}
+	for name, d := range s.Domains {
+		if d.Failed() {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,126 @@
+package engine
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/models"
+	"github.com/pkg/errors"
+)
+
+func TestRetryAndState(t *testing.T) {
+	calls := 0
+	flaky := func() error {
+		calls++
+		if calls < 3 {
+			return errors.New("try again")
+		}
+		return nil
+	}
+	broken := func() error { return errors.New("permanent") }
+	p := &fakeProvider{corrections: []*models.Correction{{Msg: "flaky", F: flaky}, {Msg: "broken", F: broken}}}
+	ch, err := Push(context.Background(), makeConfig(p), Options{Retries: 2, RetryBackoff: time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	state := NewState()
+	for res := range ch {
+		state.Record(res)
+	}
+	ps := state.Domains["example.com"].Providers["dsp"]
+	if len(ps.Succeeded) != 1 || ps.Succeeded[0] != "flaky" {
+		t.Errorf("expected flaky correction to succeed after retries: %+v", ps.Succeeded)
+	}
+	if len(ps.Failed) != 1 || ps.Failed[0].Msg != "broken" || ps.Failed[0].Attempts != 3 {
+		t.Errorf("expected broken correction to fail after 3 attempts: %+v", ps.Failed)
+	}
+
+	dir, err := ioutil.TempDir("", "dnscontrol-state")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "state.json")
+	if err := state.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadState(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if failed := loaded.FailedDomains(); len(failed) != 1 || failed[0] != "example.com" {
+		t.Errorf("expected example.com to need resuming, got %v", failed)
+	}
+}
+
+func TestResumeAfterAbort(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &fakeProvider{corrections: []*models.Correction{{Msg: "one", F: func() error { cancel(); return nil }}}}
+	cfg := makeConfig(p)
+	second := *cfg.Domains[0]
+	second.Name = "example.net"
+	cfg.Domains = append(cfg.Domains, &second)
+
+	state := NewState()
+	state.Expect("example.com", "example.net")
+	ch, err := Push(ctx, cfg, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for res := range ch {
+		state.Record(res)
+	}
+	if failed := state.FailedDomains(); len(failed) != 2 {
+		t.Errorf("expected both domains to need resuming after cancelling, got %v", failed)
+	}
+
+	// Resume the pending domains; this time nothing stops the push.
+	p.corrections = []*models.Correction{{Msg: "one", F: func() error { return nil }}}
+	resumed := NewState()
+	resumed.Expect(state.FailedDomains()...)
+	ch, err = Push(context.Background(), cfg, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for res := range ch {
+		resumed.Record(res)
+	}
+	if failed := resumed.FailedDomains(); len(failed) != 0 {
+		t.Errorf("expected nothing left to resume, got %v", failed)
+	}
+
+	// An aborted push leaves the domain it was working on pending.
+	aborted := NewState()
+	aborted.Expect("example.com")
+	aborted.Record(Result{Type: DomainStarted, Domain: "example.com"})
+	aborted.Record(Result{Type: Aborted, Err: errors.New("approval refused")})
+	if failed := aborted.FailedDomains(); len(failed) != 1 || aborted.Aborted == "" {
+		t.Errorf("expected example.com to need resuming after an abort, got %v", failed)
+	}
+}
+
+func TestMergeAndLoadMissing(t *testing.T) {
+	previous, err := LoadState(filepath.Join(os.TempDir(), "dnscontrol-no-such-state.json"))
+	if err != nil || len(previous.Domains) != 0 {
+		t.Fatalf("expected an empty state for a missing file, got %+v, %v", previous, err)
+	}
+	previous.domain("failed.com").Error = "locked"
+	previous.domain("ok.com")
+	previous.domain("fixed.com").Error = "locked"
+
+	// This push only ran fixed.com, and it succeeded.
+	state := NewState()
+	state.Expect("fixed.com")
+	state.Record(Result{Type: DomainCompleted, Domain: "fixed.com"})
+	state.Merge(previous)
+	if failed := state.FailedDomains(); len(failed) != 1 || failed[0] != "failed.com" {
+		t.Errorf("expected only the failure of the domain that was not pushed to be kept, got %v", failed)
+	}
+	if state.Domains["ok.com"] != nil {
+		t.Errorf("expected succeeded domains of the previous push to be dropped")
+	}
+}
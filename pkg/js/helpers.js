@@ -9,6 +9,9 @@ var conf = {
 
 var defaultArgs = [];
 
+// Domain templates declared with DOMAIN_TEMPLATE, by name.
+var templates = {};
+
 function initialize() {
     conf = {
         registrars: [],
@@ -16,6 +19,7 @@ function initialize() {
         domains: [],
     };
     defaultArgs = [];
+    templates = {};
 }
 
 function NewRegistrar(name, type, meta) {
@@ -36,9 +40,20 @@ function NewDnsProvider(name, type, meta) {
     return name;
 }
 
+// parseDomainName splits "example.com!view" into its name and view.
+function parseDomainName(name) {
+    var parts = name.split('!');
+    if (parts.length > 2 || parts[0] === '' || parts[1] === '') {
+        throw 'Invalid domain name ' + JSON.stringify(name) + ': use "example.com" or "example.com!view"';
+    }
+    return { name: parts[0], view: parts.length === 2 ? parts[1] : '' };
+}
+
 function newDomain(name, registrar) {
+    var parsed = parseDomainName(name);
     return {
-        name: name,
+        name: parsed.name,
+        view: parsed.view,
         registrar: registrar,
         meta: {},
         records: [],
@@ -46,6 +61,7 @@ function newDomain(name, registrar) {
         defaultTTL: 0,
         nameservers: [],
         ignored_labels: [],
+        tags: [],
     };
 }
 
@@ -87,6 +103,114 @@ function D(name, registrar) {
     conf.domain_names.push(name);
 }
 
+// D_EXTEND(name): Add records and mods to a domain that was already declared with D().
+// If name is a subdomain of a declared domain (e.g. "team.example.com"),
+// labels and relative targets are made relative to that subdomain.
+function D_EXTEND(name) {
+    var parsed = parseDomainName(name);
+    var domain = findDomain(parsed.name, parsed.view);
+    if (domain === undefined) {
+        throw 'D_EXTEND: ' + name + ' must be declared with D() before it can be extended';
+    }
+    var fqdn = parsed.name;
+    var sub = fqdn === domain.name ? '' : fqdn.slice(0, -(domain.name.length + 1));
+    var firstRecord = domain.records.length;
+    var firstIgnore = domain.ignored_labels.length;
+    // DefaultTTL() only applies to the records in this call.
+    var defaultTTL = domain.defaultTTL;
+    for (var i = 1; i < arguments.length; i++) {
+        processDargs(arguments[i], domain);
+    }
+    domain.defaultTTL = defaultTTL;
+    if (sub === '') {
+        return;
+    }
+    for (var i = firstRecord; i < domain.records.length; i++) {
+        var r = domain.records[i];
+        r.name = subdomainLabel(r.name, sub);
+        if (_.contains(['ALIAS', 'CNAME', 'MX', 'NS', 'SRV'], r.type)) {
+            r.target = subdomainTarget(r.target, fqdn);
+        }
+    }
+    for (var i = firstIgnore; i < domain.ignored_labels.length; i++) {
+        domain.ignored_labels[i] = subdomainLabel(domain.ignored_labels[i], sub);
+    }
+}
+
+// findDomain returns the domain declared as name (in view), or else the declared
+// domain in view that is the closest parent of name.
+function findDomain(name, view) {
+    var found;
+    for (var i = 0; i < conf.domains.length; i++) {
+        var d = conf.domains[i];
+        if (d.view !== view) {
+            continue;
+        }
+        if (d.name === name) {
+            return d;
+        }
+        if (name.slice(-(d.name.length + 1)) === '.' + d.name &&
+            (found === undefined || d.name.length > found.name.length)) {
+            found = d;
+        }
+    }
+    return found;
+}
+
+// subdomainLabel converts a label relative to sub into one relative to its parent domain.
+function subdomainLabel(label, sub) {
+    return label === '@' ? sub : label + '.' + sub;
+}
+
+// subdomainTarget qualifies a relative target ("@" or a single label) with the subdomain fqdn.
+function subdomainTarget(target, fqdn) {
+    if (!_.isString(target)) {
+        return target;
+    }
+    if (target === '@') {
+        return fqdn + '.';
+    }
+    if (target.indexOf('.') === -1) {
+        return target + '.' + fqdn + '.';
+    }
+    return target;
+}
+
+// DOMAIN_TEMPLATE(name, fn): Declare a template for USE_TEMPLATE.
+// fn(params) returns the records and modifiers to add to the domain.
+function DOMAIN_TEMPLATE(name, fn) {
+    if (!_.isString(name) || name === '') {
+        throw 'DOMAIN_TEMPLATE: the name must be a non-empty string';
+    }
+    if (!_.isFunction(fn)) {
+        throw 'DOMAIN_TEMPLATE: ' + name + ': the template must be a function';
+    }
+    if (_.has(templates, name)) {
+        throw 'DOMAIN_TEMPLATE: ' + name + ' is declared more than once';
+    }
+    templates[name] = fn;
+}
+
+// USE_TEMPLATE(name, params): Add the records and modifiers of a DOMAIN_TEMPLATE.
+// Records are marked with the template name in their "template" metadata.
+function USE_TEMPLATE(name, params) {
+    if (!_.has(templates, name)) {
+        throw 'USE_TEMPLATE: unknown template ' + name + ' (declare it with DOMAIN_TEMPLATE first)';
+    }
+    var mods = templates[name](params || {});
+    return function(d) {
+        var first = d.records.length;
+        processDargs(mods === undefined ? [] : mods, d);
+        for (var i = first; i < d.records.length; i++) {
+            var r = d.records[i];
+            // Keep the innermost template if templates are nested.
+            if (r.meta.template === undefined) {
+                r.meta.template = name;
+            }
+        }
+    };
+}
+
 // DEFAULTS provides a set of default arguments to apply to all future domains.
 // Each call to DEFAULTS will clear any previous values set.
 function DEFAULTS() {
@@ -111,7 +235,7 @@ function stringToDuration(v) {
     if (matches == null) {
         throw v + ' is not a valid duration string';
     }
-    unit = 's';
+    var unit = 's';
     if (matches[2]) {
         unit = matches[2];
     }
@@ -392,6 +516,24 @@ function IGNORE(name) {
     };
 }
 
+// TAGS(tag, ...): Attach tags to the domain, to select it with --tags.
+function TAGS() {
+    var tags = _.flatten(arguments);
+    for (var i = 0; i < tags.length; i++) {
+        var t = tags[i];
+        if (!_.isString(t) || t === '' || t.indexOf(',') !== -1 || t[0] === '!') {
+            throw 'TAGS: invalid tag ' + JSON.stringify(t) + ': tags must be non-empty strings without commas, not starting with !';
+        }
+    }
+    return function(d) {
+        for (var i = 0; i < tags.length; i++) {
+            if (d.tags.indexOf(tags[i]) === -1) {
+                d.tags.push(tags[i]);
+            }
+        }
+    };
+}
+
 // IMPORT_TRANSFORM(translation_table, domain)
 var IMPORT_TRANSFORM = recordBuilder('IMPORT_TRANSFORM', {
     args: [['translation_table'], ['domain'], ['ttl', _.isNumber]],
@@ -510,6 +652,9 @@ function recordBuilder(type, opts) {
             modifiers.push(arguments[i]);
         }
 
+        // Remember where the record was written, for error messages.
+        var source = sourceLocation();
+
         return function(d) {
             var record = {
                 type: type,
@@ -519,6 +664,9 @@ function recordBuilder(type, opts) {
 
             opts.applyModifier(record, modifiers);
             opts.transform(record, parsedArgs, modifiers);
+            if (source) {
+                record.meta.source_location = source;
+            }
 
             d.records.push(record);
             return record;
@@ -526,6 +674,23 @@ function recordBuilder(type, opts) {
     };
 }
 
+// sourceLocation returns "file:line" of the innermost caller outside of
+// helpers.js, or undefined if the stack can't be parsed.
+function sourceLocation() {
+    var stack = new Error().stack;
+    if (!_.isString(stack)) {
+        return undefined;
+    }
+    var lines = stack.split('\n');
+    for (var i = 0; i < lines.length; i++) {
+        var m = lines[i].match(/^\s*at (?:.*\()?(.+):(\d+):\d+(?:\(\d+\))?\)?$/);
+        if (m && m[1] !== 'helpers.js' && m[1] !== 'underscore.js') {
+            return m[1] + ':' + m[2];
+        }
+    }
+    return undefined;
+}
+
 /**
  * @deprecated
  */
@@ -567,6 +732,10 @@ function addRecord(d, type, name, target, mods) {
             }
         }
     }
+    var source = sourceLocation();
+    if (source) {
+        rec.meta.source_location = source;
+    }
     d.records.push(rec);
     return rec;
 }
@@ -664,9 +833,9 @@ function SPF_BUILDER(value) {
         value.raw = '_rawspf';
     }
 
-    r = []; // The list of records to return.
-    p = {}; // The metaparameters to set on the main TXT record.
-    rawspf = value.parts.join(' '); // The unaltered SPF settings.
+    var r = []; // The list of records to return.
+    var p = {}; // The metaparameters to set on the main TXT record.
+    var rawspf = value.parts.join(' '); // The unaltered SPF settings.
 
     // If flattening is requested, generate a TXT record with the raw SPF settings.
     if (value.flatten && value.flatten.length > 0) {
@@ -714,7 +883,7 @@ function CAA_BUILDER(value) {
         throw 'CAA_BUILDER requires at least one entry at issue or issuewild';
     }
 
-    r = []; // The list of records to return.
+    var r = []; // The list of records to return.
 
     if (value.iodef) {
         if (value.iodef_critical) {
@@ -737,9 +906,188 @@ function CAA_BUILDER(value) {
 
 // Split a DKIM string if it is >254 bytes.
 function DKIM(arr) {
-    chunkSize = 255;
+    var chunkSize = 255;
     var R = [];
     for (var i = 0, len = arr.length; i < len; i += chunkSize)
         R.push(arr.slice(i, i + chunkSize));
     return R;
 }
+
+// Vendor record sets.
+//
+// The helpers below produce the records that SaaS vendors document for their
+// services. When a vendor changes its documentation, a new version of its
+// record set is added here and vendorVersions is bumped. Each helper uses the
+// latest version unless {version: N} is given. The records are tagged with
+// vendor metadata, which lets `dnscontrol check` warn about outdated versions.
+
+var vendorVersions = {
+    fastmail: 1,
+    google_workspace: 2,
+    mailgun: 1,
+    microsoft_365: 1,
+};
+
+// vendorMeta returns the metadata modifier for the records of a vendor.
+function vendorMeta(vendor, opts) {
+    var latest = vendorVersions[vendor];
+    var version = opts.version === undefined ? latest : opts.version;
+    if (!_.isNumber(version) || version % 1 !== 0 || version < 1 || version > latest) {
+        throw vendor + ': unknown version ' + version + ' (known versions are 1 to ' + latest + ')';
+    }
+    return {
+        vendor: vendor,
+        vendor_version: '' + version,
+        vendor_latest: '' + latest,
+    };
+}
+
+// vendorToken strips an optional prefix from a verification token.
+function vendorToken(token, prefix) {
+    return token.indexOf(prefix) === 0 ? token.substr(prefix.length) : token;
+}
+
+// vendorLabel returns the label of a record under an optional subdomain.
+function vendorLabel(label, subdomain) {
+    if (!subdomain || subdomain === '@') {
+        return label;
+    }
+    return label === '@' ? subdomain : label + '.' + subdomain;
+}
+
+// vendorRecords adds the records r to the domain d. Records built when the
+// domain is processed are reported at the line of the vendor helper.
+function vendorRecords(r, d, source) {
+    var n = d.records.length;
+    processDargs(r, d);
+    if (source) {
+        for (var i = n; i < d.records.length; i++) {
+            d.records[i].meta.source_location = source;
+        }
+    }
+}
+
+// GOOGLE_WORKSPACE_MX({label, version}): The MX records of Google Workspace.
+function GOOGLE_WORKSPACE_MX(opts) {
+    opts = opts || {};
+    var meta = vendorMeta('google_workspace', opts);
+    var label = opts.label || '@';
+    if (meta.vendor_version === '1') {
+        return [
+            MX(label, 1, 'aspmx.l.google.com.', meta),
+            MX(label, 5, 'alt1.aspmx.l.google.com.', meta),
+            MX(label, 5, 'alt2.aspmx.l.google.com.', meta),
+            MX(label, 10, 'alt3.aspmx.l.google.com.', meta),
+            MX(label, 10, 'alt4.aspmx.l.google.com.', meta),
+        ];
+    }
+    return [MX(label, 1, 'smtp.google.com.', meta)];
+}
+
+// GOOGLE_WORKSPACE({label, version, spf, verification, dkim, dkim_selector}):
+// The MX, SPF, site verification and DKIM records of Google Workspace.
+function GOOGLE_WORKSPACE(opts) {
+    opts = opts || {};
+    var meta = vendorMeta('google_workspace', opts);
+    var label = opts.label || '@';
+    var r = GOOGLE_WORKSPACE_MX(opts);
+    if (opts.spf !== false) {
+        r.push(TXT(label, 'v=spf1 include:_spf.google.com ~all', meta));
+    }
+    if (opts.verification) {
+        r.push(TXT(label, 'google-site-verification=' + vendorToken(opts.verification, 'google-site-verification='), meta));
+    }
+    if (opts.dkim) {
+        var selector = opts.dkim_selector || 'google';
+        r.push(TXT(vendorLabel(selector + '._domainkey', label), DKIM(opts.dkim), meta));
+    }
+    return r;
+}
+
+// M365({tenant, mx_token, version, spf, verification, teams, mdm}): The
+// Exchange Online records of Microsoft 365. tenant is the name of the
+// tenant's initial domain (tenant.onmicrosoft.com). mx_token defaults to the
+// domain name with dots replaced by dashes.
+function M365(opts) {
+    opts = opts || {};
+    if (!_.isString(opts.tenant) || opts.tenant === '') {
+        throw 'M365 requires the tenant option';
+    }
+    var meta = vendorMeta('microsoft_365', opts);
+    var source = sourceLocation();
+    return function(d) {
+        var token = opts.mx_token || d.name.replace(/\./g, '-');
+        var r = [
+            MX('@', 0, token + '.mail.protection.outlook.com.', meta),
+            CNAME('autodiscover', 'autodiscover.outlook.com.', meta),
+            CNAME('selector1._domainkey', 'selector1-' + token + '._domainkey.' + opts.tenant + '.onmicrosoft.com.', meta),
+            CNAME('selector2._domainkey', 'selector2-' + token + '._domainkey.' + opts.tenant + '.onmicrosoft.com.', meta),
+        ];
+        if (opts.spf !== false) {
+            r.push(TXT('@', 'v=spf1 include:spf.protection.outlook.com -all', meta));
+        }
+        if (opts.verification) {
+            r.push(TXT('@', 'MS=' + vendorToken(opts.verification, 'MS='), meta));
+        }
+        if (opts.teams) {
+            r.push(CNAME('sip', 'sipdir.online.lync.com.', meta));
+            r.push(CNAME('lyncdiscover', 'webdir.online.lync.com.', meta));
+            r.push(SRV('_sip._tls', 100, 1, 443, 'sipdir.online.lync.com.', meta));
+            r.push(SRV('_sipfederationtls._tcp', 100, 1, 5061, 'sipfed.online.lync.com.', meta));
+        }
+        if (opts.mdm) {
+            r.push(CNAME('enterpriseregistration', 'enterpriseregistration.windows.net.', meta));
+            r.push(CNAME('enterpriseenrollment', 'enterpriseenrollment-s.manage.microsoft.com.', meta));
+        }
+        vendorRecords(r, d, source);
+    };
+}
+
+// FASTMAIL({version, spf}): The MX, SPF and DKIM records of Fastmail.
+function FASTMAIL(opts) {
+    opts = opts || {};
+    var meta = vendorMeta('fastmail', opts);
+    var source = sourceLocation();
+    return function(d) {
+        var r = [
+            MX('@', 10, 'in1-smtp.messagingengine.com.', meta),
+            MX('@', 20, 'in2-smtp.messagingengine.com.', meta),
+        ];
+        if (opts.spf !== false) {
+            r.push(TXT('@', 'v=spf1 include:spf.messagingengine.com ?all', meta));
+        }
+        for (var i = 1; i <= 3; i++) {
+            r.push(CNAME('fm' + i + '._domainkey', 'fm' + i + '.' + d.name + '.dkim.fmhosted.com.', meta));
+        }
+        vendorRecords(r, d, source);
+    };
+}
+
+// MAILGUN({region, subdomain, version, spf, dkim, dkim_selector, tracking}):
+// The records of a Mailgun sending domain. region is 'us' (default) or 'eu'.
+// subdomain is the label of the sending domain, if it is not the zone apex.
+function MAILGUN(opts) {
+    opts = opts || {};
+    var meta = vendorMeta('mailgun', opts);
+    var region = opts.region || 'us';
+    if (region !== 'us' && region !== 'eu') {
+        throw 'MAILGUN: unknown region ' + region + " (use 'us' or 'eu')";
+    }
+    var host = region === 'eu' ? 'eu.mailgun.org.' : 'mailgun.org.';
+    var label = opts.subdomain || '@';
+    var r = [
+        MX(label, 10, 'mxa.' + host, meta),
+        MX(label, 10, 'mxb.' + host, meta),
+    ];
+    if (opts.spf !== false) {
+        r.push(TXT(label, 'v=spf1 include:mailgun.org ~all', meta));
+    }
+    if (opts.dkim) {
+        var selector = opts.dkim_selector || 'smtp';
+        r.push(TXT(vendorLabel(selector + '._domainkey', label), DKIM(opts.dkim), meta));
+    }
+    if (opts.tracking !== false) {
+        r.push(CNAME(vendorLabel('email', label), host, meta));
+    }
+    return r;
+}
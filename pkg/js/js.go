@@ -4,15 +4,18 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/StackExchange/dnscontrol/models"
+	"github.com/StackExchange/dnscontrol/pkg/natsort"
 	"github.com/StackExchange/dnscontrol/pkg/printer"
 	"github.com/StackExchange/dnscontrol/pkg/transform"
+	"github.com/dop251/goja"
 	"github.com/pkg/errors"
-	"github.com/robertkrimen/otto"              // load underscore js into vm by default
-	_ "github.com/robertkrimen/otto/underscore" // required by otto
+	"github.com/robertkrimen/otto/underscore"
 )
 
 // currentDirectory is the current directory as used by require().
@@ -23,8 +26,15 @@ import (
 // far as require() is concerned, not the actual os.Getwd().
 var currentDirectory string
 
+// Variables are the values given to dnsconfig.js on the command line.
+type Variables struct {
+	CLI        map[string]string // Exposed as the frozen CLI_VARS object.
+	AllowedEnv []string          // Environment variables that ENV() may read.
+}
+
 // ExecuteJavascript accepts a javascript string and runs it, returning the resulting dnsConfig.
-func ExecuteJavascript(file string, devMode bool) (*models.DNSConfig, error) {
+// The CLI variables and the environment variables read by ENV() are recorded in the dnsConfig.
+func ExecuteJavascript(file string, devMode bool, variables Variables, limits Limits) (*models.DNSConfig, error) {
 	script, err := ioutil.ReadFile(file)
 	if err != nil {
 		return nil, errors.Errorf("Reading js file %s: %s", file, err)
@@ -33,35 +43,58 @@ func ExecuteJavascript(file string, devMode bool) (*models.DNSConfig, error) {
 	// Record the directory path leading up to this file.
 	currentDirectory = filepath.Clean(filepath.Dir(file))
 
-	vm := otto.New()
+	root := ""
+	if limits.Sandbox {
+		if root, err = sandboxRoot(file); err != nil {
+			return nil, err
+		}
+	}
+
+	vm := goja.New()
+	defer watch(vm, limits)()
+
+	// The files run so far, which require_glob() does not run again.
+	loaded := map[string]bool{loadedKey(file): true}
+	vm.Set("require", require(vm, root, loaded))
+	vm.Set("require_glob", requireGlob(vm, root, loaded))
+	vm.Set("REV", reverse(vm))
+	usedEnv := map[string]string{}
+	vm.Set("ENV", env(vm, variables.AllowedEnv, usedEnv))
+	if err := setCLIVars(vm, variables.CLI); err != nil {
+		return nil, err
+	}
 
-	vm.Set("require", require)
-	vm.Set("REV", reverse)
+	// load underscore.js, which helpers.js depends on
+	if _, err := vm.RunScript("underscore.js", underscore.Source()); err != nil {
+		return nil, err
+	}
 
 	helperJs := GetHelpers(devMode)
 	// run helper script to prime vm and initialize variables
-	if _, err := vm.Run(helperJs); err != nil {
+	if _, err := vm.RunScript("helpers.js", helperJs); err != nil {
 		return nil, err
 	}
 
 	// run user script
-	if _, err := vm.Run(script); err != nil {
+	if _, err := vm.RunScript(file, string(script)); err != nil {
 		return nil, err
 	}
 
 	// export conf as string and unmarshal
-	value, err := vm.Run(`JSON.stringify(conf)`)
-	if err != nil {
-		return nil, err
-	}
-	str, err := value.ToString()
+	value, err := vm.RunString(`JSON.stringify(conf)`)
 	if err != nil {
 		return nil, err
 	}
 	conf := &models.DNSConfig{}
-	if err = json.Unmarshal([]byte(str), conf); err != nil {
+	if err = json.Unmarshal([]byte(value.String()), conf); err != nil {
 		return nil, err
 	}
+	if len(variables.CLI) > 0 {
+		conf.CLIVars = variables.CLI
+	}
+	if len(usedEnv) > 0 {
+		conf.Env = usedEnv
+	}
 	return conf, nil
 }
 
@@ -70,44 +103,77 @@ func GetHelpers(devMode bool) string {
 	return _escFSMustString(devMode, "/helpers.js")
 }
 
-func require(call otto.FunctionCall) otto.Value {
-	if len(call.ArgumentList) != 1 {
-		throw(call.Otto, "require takes exactly one argument")
+// GetTypes returns the TypeScript declarations of the functions of dnsconfig.js, or the esc'ed version.
+func GetTypes(devMode bool) string {
+	return _escFSMustString(devMode, "/types-dnscontrol.d.ts")
+}
+
+// require returns the require() function for vm. If root is not empty,
+// only files in the directory root can be required. Required files are
+// added to loaded.
+func require(vm *goja.Runtime, root string, loaded map[string]bool) func(call goja.FunctionCall) goja.Value {
+	return func(call goja.FunctionCall) goja.Value {
+		if len(call.Arguments) != 1 {
+			throw(vm, "require takes exactly one argument")
+		}
+		file := call.Argument(0).String() // The filename as given by the user
+		relFile, dir := requirePath(file)
+		return requireFile(vm, root, loaded, file, relFile, dir)
 	}
-	file := call.Argument(0).String() // The filename as given by the user
+}
 
+// requirePath returns the file that require(file) reads (relFile) and the
+// directory that becomes the currentDirectory while it runs.
+func requirePath(file string) (relFile, dir string) {
 	// relFile is the file we're actually going to pass to ReadFile().
 	// It defaults to the user-provided name unless it is relative.
-	relFile := file
+	relFile = file
 	cleanFile := filepath.Clean(filepath.Join(currentDirectory, file))
 	if strings.HasPrefix(file, ".") {
 		relFile = cleanFile
 	}
+	return relFile, filepath.Clean(filepath.Dir(cleanFile))
+}
+
+// loadedKey identifies file in the set of loaded files, whatever path it was loaded by.
+func loadedKey(file string) string {
+	if resolved, err := resolvePath(file); err == nil {
+		return resolved
+	}
+	return filepath.Clean(file)
+}
+
+// requireFile runs relFile (or parses it if it is json) with dir as the currentDirectory.
+func requireFile(vm *goja.Runtime, root string, loaded map[string]bool, file, relFile, dir string) goja.Value {
+	if err := checkSandbox(root, relFile); err != nil {
+		throw(vm, fmt.Sprintf("require(%q): %s", file, err))
+	}
+	loaded[loadedKey(relFile)] = true
 
 	// Record the old currentDirectory so that we can return there.
 	currentDirectoryOld := currentDirectory
 	// Record the directory path leading up to the file we're about to require.
-	currentDirectory = filepath.Clean(filepath.Dir(cleanFile))
+	currentDirectory = dir
 
 	printer.Debugf("requiring: %s (%s)\n", file, relFile)
 	data, err := ioutil.ReadFile(relFile)
 
 	if err != nil {
-		throw(call.Otto, err.Error())
+		throw(vm, err.Error())
 	}
 
-	var value otto.Value = otto.TrueValue()
+	var value goja.Value = vm.ToValue(true)
 
 	// If its a json file return the json value, else default to true
 	if strings.HasSuffix(filepath.Ext(relFile), "json") {
 		cmd := fmt.Sprintf(`JSON.parse(JSON.stringify(%s))`, string(data))
-		value, err = call.Otto.Run(cmd)
+		value, err = vm.RunScript(relFile, cmd)
 	} else {
-		_, err = call.Otto.Run(string(data))
+		_, err = vm.RunScript(relFile, string(data))
 	}
 
 	if err != nil {
-		throw(call.Otto, err.Error())
+		throw(vm, err.Error())
 	}
 
 	// Pop back to the old directory.
@@ -116,19 +182,122 @@ func require(call otto.FunctionCall) otto.Value {
 	return value
 }
 
-func throw(vm *otto.Otto, str string) {
-	panic(vm.MakeCustomError("Error", str))
-}
+// requireGlob returns the require_glob(path, recursive) function for vm.
+// It requires every .js file in the directory path (and its subdirectories
+// unless recursive is false) in natural sort order. Files in loaded, such as
+// the config file itself, are skipped, so that a config can load its own
+// directory. If root is not empty, only directories in root can be loaded.
+func requireGlob(vm *goja.Runtime, root string, loaded map[string]bool) func(call goja.FunctionCall) goja.Value {
+	return func(call goja.FunctionCall) goja.Value {
+		if len(call.Arguments) < 1 || len(call.Arguments) > 2 {
+			throw(vm, "require_glob takes one or two arguments")
+		}
+		path := call.Argument(0).String()
+		recursive := true
+		if len(call.Arguments) == 2 {
+			recursive = call.Argument(1).ToBoolean()
+		}
+
+		// The directory is resolved like a file passed to require().
+		dir, _ := requirePath(path)
+		dir = filepath.Clean(dir)
+		if err := checkSandbox(root, dir); err != nil {
+			throw(vm, fmt.Sprintf("require_glob(%q): %s", path, err))
+		}
 
-func reverse(call otto.FunctionCall) otto.Value {
-	if len(call.ArgumentList) != 1 {
-		throw(call.Otto, "REV takes exactly one argument")
+		var files []string
+		err := filepath.Walk(dir, func(p string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() {
+				if p != dir && !recursive {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if filepath.Ext(p) == ".js" {
+				files = append(files, p)
+			}
+			return nil
+		})
+		if err != nil {
+			throw(vm, fmt.Sprintf("require_glob: %s", err))
+		}
+		sort.Slice(files, func(i, j int) bool {
+			return natsort.Less(files[i], files[j])
+		})
+
+		for _, f := range files {
+			// A file loaded meanwhile, by an earlier file of the glob, is skipped too.
+			if loaded[loadedKey(f)] {
+				continue
+			}
+			requireFile(vm, root, loaded, f, f, filepath.Dir(f))
+		}
+		return vm.ToValue(true)
 	}
-	dom := call.Argument(0).String()
-	rev, err := transform.ReverseDomainName(dom)
+}
+
+// throw raises a javascript Error with the message str.
+func throw(vm *goja.Runtime, str string) {
+	e, err := vm.New(vm.Get("Error"), vm.ToValue(str))
 	if err != nil {
-		throw(call.Otto, err.Error())
+		panic(vm.NewGoError(errors.New(str)))
+	}
+	panic(e)
+}
+
+// reverse returns the REV() function for vm.
+func reverse(vm *goja.Runtime) func(call goja.FunctionCall) goja.Value {
+	return func(call goja.FunctionCall) goja.Value {
+		if len(call.Arguments) != 1 {
+			throw(vm, "REV takes exactly one argument")
+		}
+		dom := call.Argument(0).String()
+		rev, err := transform.ReverseDomainName(dom)
+		if err != nil {
+			throw(vm, err.Error())
+		}
+		return vm.ToValue(rev)
+	}
+}
+
+// setCLIVars defines CLI_VARS in vm as a frozen, read-only object.
+func setCLIVars(vm *goja.Runtime, vars map[string]string) error {
+	obj := vm.NewObject()
+	for k, v := range vars {
+		if err := obj.Set(k, v); err != nil {
+			return err
+		}
+	}
+	freeze, _ := goja.AssertFunction(vm.Get("Object").ToObject(vm).Get("freeze"))
+	if _, err := freeze(goja.Undefined(), obj); err != nil {
+		return err
+	}
+	return vm.GlobalObject().DefineDataProperty("CLI_VARS", obj, goja.FLAG_FALSE, goja.FLAG_FALSE, goja.FLAG_TRUE)
+}
+
+// env returns the ENV() function for vm. It reads the environment
+// variables in allowed, and records the values it returns in used.
+func env(vm *goja.Runtime, allowed []string, used map[string]string) func(call goja.FunctionCall) goja.Value {
+	ok := map[string]bool{}
+	for _, name := range allowed {
+		ok[name] = true
+	}
+	return func(call goja.FunctionCall) goja.Value {
+		if len(call.Arguments) != 1 {
+			throw(vm, "ENV takes exactly one argument")
+		}
+		name := call.Argument(0).String()
+		if !ok[name] {
+			throw(vm, fmt.Sprintf("ENV(%q): %s is not allowed. Allow it with --allow-env %s", name, name, name))
+		}
+		value, found := os.LookupEnv(name)
+		if !found {
+			return goja.Undefined()
+		}
+		used[name] = value
+		return vm.ToValue(value)
 	}
-	v, _ := otto.ToValue(rev)
-	return v
 }
@@ -5,9 +5,13 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strings"
 	"testing"
+	"time"
 	"unicode"
 
+	"github.com/StackExchange/dnscontrol/models"
 	"github.com/tdewolff/minify"
 	minjson "github.com/tdewolff/minify/json"
 )
@@ -34,7 +38,7 @@ func TestParsedFiles(t *testing.T) {
 		m := minify.New()
 		m.AddFunc("json", minjson.Minify)
 		t.Run(f.Name(), func(t *testing.T) {
-			conf, err := ExecuteJavascript(string(filepath.Join(testDir, f.Name())), true)
+			conf, err := ExecuteJavascript(string(filepath.Join(testDir, f.Name())), true, Variables{}, Limits{})
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -80,10 +84,169 @@ func TestErrors(t *testing.T) {
 	}
 	for _, tst := range tests {
 		t.Run(tst.desc, func(t *testing.T) {
-			if _, err := ExecuteJavascript(tst.text, true); err == nil {
+			if _, err := ExecuteJavascript(tst.text, true, Variables{}, Limits{}); err == nil {
 				t.Fatal("Expected error but found none")
 			}
 		})
 
 	}
 }
+
+func TestVariables(t *testing.T) {
+	run := func(t *testing.T, script string, vars Variables) (*models.DNSConfig, error) {
+		f, err := ioutil.TempFile("", "variables*.js")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.Remove(f.Name())
+		if _, err := f.WriteString(script); err != nil {
+			t.Fatal(err)
+		}
+		f.Close()
+		return ExecuteJavascript(f.Name(), true, vars, Limits{})
+	}
+	os.Setenv("DNSCONTROL_TEST_IP", "1.2.3.4")
+	defer os.Unsetenv("DNSCONTROL_TEST_IP")
+	vars := Variables{
+		CLI:        map[string]string{"domain": "example.com"},
+		AllowedEnv: []string{"DNSCONTROL_TEST_IP", "DNSCONTROL_TEST_UNSET"},
+	}
+
+	t.Run("values", func(t *testing.T) {
+		conf, err := run(t, `
+var REG = NewRegistrar("Third-Party", "NONE");
+var ttl = ENV("DNSCONTROL_TEST_UNSET") || "600";
+D(CLI_VARS.domain, REG, A("@", ENV("DNSCONTROL_TEST_IP"), TTL(ttl)));
+`, vars)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if conf.Domains[0].Name != "example.com" || conf.Domains[0].Records[0].GetTargetField() != "1.2.3.4" {
+			t.Errorf("Variables not used: %s %v", conf.Domains[0].Name, conf.Domains[0].Records[0])
+		}
+		if conf.CLIVars["domain"] != "example.com" {
+			t.Errorf("CLI_VARS not recorded: %v", conf.CLIVars)
+		}
+		if len(conf.Env) != 1 || conf.Env["DNSCONTROL_TEST_IP"] != "1.2.3.4" {
+			t.Errorf("Expect only the environment variables read to be recorded: %v", conf.Env)
+		}
+	})
+
+	for _, tst := range []struct{ desc, script string }{
+		{"change CLI_VARS", `"use strict"; CLI_VARS.domain = "example.org";`},
+		{"add to CLI_VARS", `"use strict"; CLI_VARS.other = "example.org";`},
+		{"replace CLI_VARS", `"use strict"; CLI_VARS = {};`},
+		{"ENV not allowed", `ENV("HOME");`},
+	} {
+		t.Run(tst.desc, func(t *testing.T) {
+			if _, err := run(t, tst.script, vars); err == nil {
+				t.Fatal("Expected error but found none")
+			}
+		})
+	}
+}
+
+func TestLimits(t *testing.T) {
+	dir, err := ioutil.TempDir("", "limits")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	write := func(name, content string) string {
+		p := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+	write("outside.js", `var x = 1;`)
+	write("config/inside/zone.js", `var y = 2;`)
+	if err := os.Symlink(filepath.Join(dir, "outside.js"), filepath.Join(dir, "config/link.js")); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		desc, script string
+		limits       Limits
+		wantErr      string
+	}{
+		{"timeout", `while (true) {}`, Limits{Timeout: 50 * time.Millisecond}, "longer than 50ms"},
+		{"memory", `var a = []; while (true) { a.push(new Array(100000).join("x")); }`,
+			Limits{MaxMemory: 16 << 20, Timeout: time.Minute}, "more than 16 MiB"},
+		{"require outside", `require("../outside.js");`, Limits{}, ""},
+		{"sandbox require inside", `require("./inside/zone.js"); require_glob("./inside/");`, Limits{Sandbox: true}, ""},
+		{"sandbox require outside", `require("../outside.js");`, Limits{Sandbox: true}, "sandbox mode"},
+		{"sandbox require symlink", `require("./link.js");`, Limits{Sandbox: true}, "sandbox mode"},
+		{"sandbox require_glob outside", `require_glob("../");`, Limits{Sandbox: true}, "sandbox mode"},
+		{"sandbox require missing outside", `require("/nonexistent/secret.js");`, Limits{Sandbox: true}, "sandbox mode"},
+	}
+	for _, tst := range tests {
+		t.Run(tst.desc, func(t *testing.T) {
+			file := write("config/dnsconfig.js", tst.script)
+			_, err := ExecuteJavascript(file, true, Variables{}, tst.limits)
+			if tst.wantErr == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tst.wantErr) {
+				t.Fatalf("Expected error %q but got %v", tst.wantErr, err)
+			}
+		})
+	}
+
+	// The config directory is reached through a symlink, as /tmp and /var are on macOS.
+	if err := os.Symlink(filepath.Join(dir, "config"), filepath.Join(dir, "linked")); err != nil {
+		t.Fatal(err)
+	}
+	write("config/dnsconfig.js", `require("./inside/zone.js"); require_glob("./inside/");`)
+	if _, err := ExecuteJavascript(filepath.Join(dir, "linked/dnsconfig.js"), true, Variables{}, Limits{Sandbox: true}); err != nil {
+		t.Errorf("sandbox through a symlinked directory: %s", err)
+	}
+	write("config/dnsconfig.js", `require("../outside.js");`)
+	if _, err := ExecuteJavascript(filepath.Join(dir, "linked/dnsconfig.js"), true, Variables{}, Limits{Sandbox: true}); err == nil || !strings.Contains(err.Error(), "sandbox mode") {
+		t.Errorf("sandbox through a symlinked directory, require outside: expected sandbox error, got %v", err)
+	}
+}
+
+func TestRequireGlobOwnDirectory(t *testing.T) {
+	dir, err := ioutil.TempDir("", "glob")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	for name, content := range map[string]string{
+		"dnsconfig.js": `var REG = NewRegistrar("none", "NONE"); require_glob("./");`,
+		"zone.js":      `D("example.com", REG); require_glob("./");`,
+		"creds.json":   `{"bind": {"directory": "zones"}}`,
+	} {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	conf, err := ExecuteJavascript(filepath.Join(dir, "dnsconfig.js"), true, Variables{}, Limits{Timeout: 10 * time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(conf.Domains) != 1 || len(conf.Registrars) != 1 {
+		t.Errorf("expected each file to run once, got %d domains and %d registrars", len(conf.Domains), len(conf.Registrars))
+	}
+}
+
+func TestTypesAreUpToDate(t *testing.T) {
+	helpers, err := ioutil.ReadFile("pkg/js/helpers.js")
+	if err != nil {
+		t.Fatal(err)
+	}
+	types := GetTypes(true)
+	public := regexp.MustCompile(`(?m)^(?:function|var) ([A-Z]\w*)`)
+	for _, m := range public.FindAllStringSubmatch(string(helpers), -1) {
+		if !strings.Contains(types, "declare function "+m[1]+"(") && !strings.Contains(types, "declare const "+m[1]+":") {
+			t.Errorf("%s is not declared in types-dnscontrol.d.ts. Run go generate", m[1])
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package js
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/dop251/goja"
+	"github.com/pkg/errors"
+)
+
+// Limits restrict what dnsconfig.js may do. The zero value means no limits.
+type Limits struct {
+	// Timeout stops the script after it has run this long.
+	Timeout time.Duration
+	// MaxMemory stops the script when the heap has grown by this many bytes
+	// since it started. The heap is shared by the whole process, so this is
+	// approximate.
+	MaxMemory uint64
+	// Sandbox restricts require() and require_glob() to the directory of the
+	// config file and its subdirectories.
+	Sandbox bool
+}
+
+// watchInterval is how often the limits are checked.
+var watchInterval = 10 * time.Millisecond
+
+// watch interrupts vm when it exceeds limits. Call stop when vm is done.
+func watch(vm *goja.Runtime, limits Limits) (stop func()) {
+	if limits.Timeout == 0 && limits.MaxMemory == 0 {
+		return func() {}
+	}
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+	base := mem.HeapAlloc
+	start := time.Now()
+
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(watchInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+			if limits.Timeout > 0 && time.Since(start) > limits.Timeout {
+				vm.Interrupt(errors.Errorf("javascript ran longer than %s", limits.Timeout))
+				return
+			}
+			if limits.MaxMemory > 0 {
+				runtime.ReadMemStats(&mem)
+				if mem.HeapAlloc > base && mem.HeapAlloc-base > limits.MaxMemory {
+					vm.Interrupt(errors.Errorf("javascript used more than %d MiB of memory", limits.MaxMemory>>20))
+					return
+				}
+			}
+		}
+	}()
+	return func() { close(done) }
+}
+
+// sandboxRoot returns the directory that require() is restricted to when
+// file is run in sandbox mode.
+func sandboxRoot(file string) (string, error) {
+	dir, err := filepath.Abs(filepath.Dir(file))
+	if err != nil {
+		return "", err
+	}
+	return filepath.EvalSymlinks(dir)
+}
+
+// checkSandbox returns an error if path is outside of root, after following
+// symlinks. An empty root means there is no sandbox. root must already be
+// resolved, as sandboxRoot returns it.
+func checkSandbox(root, path string) error {
+	if root == "" {
+		return nil
+	}
+	resolved, err := resolvePath(path)
+	if err != nil {
+		return err
+	}
+	if !inside(root, resolved) {
+		return errors.Errorf("%s is outside of %s (sandbox mode)", path, root)
+	}
+	return nil
+}
+
+// resolvePath returns the absolute path with symlinks followed. For a path
+// that does not exist, the nearest existing parent directory is resolved, so
+// that a missing file is compared to the root the same way as an existing one.
+func resolvePath(path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	resolved, err := filepath.EvalSymlinks(abs)
+	if err == nil || !os.IsNotExist(err) {
+		return resolved, err
+	}
+	parent := filepath.Dir(abs)
+	if parent == abs {
+		return abs, nil
+	}
+	resolved, err = resolvePath(parent)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(resolved, filepath.Base(abs)), nil
+}
+
+// inside reports whether path is root or in a subdirectory of root.
+func inside(root, path string) bool {
+	rel, err := filepath.Rel(root, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(os.PathSeparator))
+}
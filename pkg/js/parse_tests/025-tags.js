@@ -0,0 +1,4 @@
+D("foo.com","none",
+    TAGS("prod","eu"),
+    TAGS("prod")
+);
@@ -0,0 +1,15 @@
+const REG = NewRegistrar("Third-Party", "NONE");
+const CF = NewDnsProvider("Cloudflare", "CLOUDFLAREAPI");
+
+const hosts = { www: "1.2.3.4", mail: "1.2.3.5" };
+const records = Object.entries(hosts).map(([name, ip]) => A(name, ip));
+
+let names = ["foo", "bar"];
+for (const n of names) {
+    records.push(CNAME(`${n}-alias`, `${n}.example.com.`));
+}
+
+const { www } = hosts;
+const extra = names.includes("foo") ? [TXT("@", `www is ${www}`)] : [];
+
+D("foo.com", REG, DnsProvider(CF), ...records, ...extra);
@@ -0,0 +1,22 @@
+D("foo.com", "none",
+    A("@", "1.2.3.4")
+);
+D("bar.foo.com", "none",
+    A("@", "1.2.3.5")
+);
+D_EXTEND("foo.com",
+    A("www", "1.2.3.6"),
+    IGNORE("legacy"),
+    {extended: "true"}
+);
+D_EXTEND("team.foo.com",
+    DefaultTTL(300),
+    A("@", "1.2.3.7"),
+    A("api", "1.2.3.8"),
+    CNAME("web", "api"),
+    MX("@", 10, "@"),
+    IGNORE("tmp")
+);
+D_EXTEND("x.bar.foo.com",
+    A("@", "1.2.3.9")
+);
@@ -0,0 +1 @@
+require_glob("./globImports/");
@@ -0,0 +1,12 @@
+D("foo.com", "none",
+    A("@", "1.2.3.4")
+);
+D("foo.com!internal", "none",
+    A("@", "10.0.0.1")
+);
+D_EXTEND("foo.com!internal",
+    A("db", "10.0.0.2")
+);
+D_EXTEND("team.foo.com!internal",
+    A("api", "10.0.0.3")
+);
@@ -0,0 +1,25 @@
+var REG = NewRegistrar("Third-Party", "NONE");
+
+DOMAIN_TEMPLATE("mail", function(p) {
+    return [
+        MX("@", 10, p.mx),
+        TXT("@", "v=spf1 mx -all")
+    ];
+});
+
+DOMAIN_TEMPLATE("parked", function(p) {
+    return [
+        A("@", p.ip),
+        A("www", p.ip),
+        USE_TEMPLATE("mail", {mx: "mx." + p.zone + "."}),
+        {parked: "true"}
+    ];
+});
+
+D("brand1.com", REG,
+    USE_TEMPLATE("parked", {ip: "1.2.3.4", zone: "brand1.com"}),
+    A("shop", "1.2.3.5")
+);
+D("brand2.com", REG,
+    USE_TEMPLATE("parked", {ip: "1.2.3.6", zone: "brand2.com"})
+);
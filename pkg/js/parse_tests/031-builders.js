@@ -0,0 +1,14 @@
+D("foo.com", "none",
+    SPF_BUILDER({
+        label: "@",
+        overflow: "_spf%d",
+        raw: "_rawspf",
+        parts: ["v=spf1", "include:_spf.google.com", "~all"]
+    }),
+    CAA_BUILDER({
+        label: "@",
+        iodef: "mailto:test@example.com",
+        issue: ["letsencrypt.org"],
+        issuewild: "none"
+    })
+);
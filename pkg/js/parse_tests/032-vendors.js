@@ -0,0 +1,11 @@
+var REG = NewRegistrar("Third-Party", "NONE");
+
+D("example.com", REG,
+    GOOGLE_WORKSPACE({verification: "abc123"}),
+    MAILGUN({region: "eu", subdomain: "mg"})
+);
+D("example.org", REG,
+    GOOGLE_WORKSPACE_MX({version: 1}),
+    M365({tenant: "contoso"}),
+    FASTMAIL({spf: false})
+);
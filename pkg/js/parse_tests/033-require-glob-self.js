@@ -0,0 +1 @@
+require("./selfGlob/main.js");
@@ -0,0 +1 @@
+var COMMON = [TXT("@", "loaded from sub/helper.js")];
@@ -0,0 +1 @@
+D("zone10.com", "none", A("@", "1.2.3.10"), COMMON);
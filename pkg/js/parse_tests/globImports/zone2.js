@@ -0,0 +1 @@
+D("zone2.com", "none", A("@", "1.2.3.2"), COMMON);
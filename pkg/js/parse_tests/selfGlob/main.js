@@ -0,0 +1,3 @@
+var REG = NewRegistrar("none", "NONE");
+// Loads the other files of this directory, but not main.js again or creds.json.
+require_glob("./", false);
@@ -0,0 +1 @@
+D("self.com", REG, A("@", "1.2.3.4"));
@@ -192,103 +192,250 @@ var _escData = map[string]*_escFile{
 
 	"/helpers.js": {
 		local:   "pkg/js/helpers.js",
-		size:    21786,
+		size:    34857,
 		modtime: 0,
 		compressed: `
-H4sIAAAAAAAC/+w8a3PbOJLf/Ss6qduhmDD0I5PsljzaW40fs671qyRlNns+nQoWIQkTCuQBoBXvjPPb
-r/AiAT5kTWpn9svlQyyCjX6h0d0AGgwKjoELRuYiON7be0AM5hldwAB+3gMAYHhJuGCI8T7cTSPVllA+
-y1n2QBLsNWdrRGijYUbRGpvWJ0MiwQtUpGLIlhwGcDc93ttbFHQuSEaBUCIISsk/cS80THgcdXG1hbNW
-7p6ONZMNVp4cZq7xZmRp9aQgEYjHHEewxgJZ9sgCerI1dDiUzzAYQHA1vP4wvAw0sSf1v9QAw0spEUic
-fagw9x38ffW/ZVQqIa4Ej/OCr3oML8NjM1CiYFRhaohwSvmt0cqzQmQLTXUgmc/uf8JzEcA330BA8tk8
-ow+YcZJRHgChXn/5Tz7HPhwMYJGxNRIzIXot78O6YhKef41ivJHXukl4/pxuKN6cKrswainVG5bmr3pW
-IjpsNa2xX/2MPKX04ecnF36esaRpureV5brgxkInk8s+HEQeJxyzh4alkyXNGE5mKbrHqW/wruw5y+aY
-81PElry3jswEsYLv78txA4zmK1hnCVkQzCJpJEQA4YDiOC7hDMY+zFGaSoANESuDzwIhxtBj3xKVKigY
-Jw84fbQQ2tbk0LIlVmSoyJT2EiRQaaOzmPBzQ7G3Dj3z6xkZjE0BTjkuOw0lB7UeUsSetLqflDm7r+Q/
-X0V3P01LLR2XcE9ttG6ULDVisxh/FpgmhstYihbB2ufW8SArlm0g+PtwdH1x/UPfUC4HQ3uYgvIizzMm
-cNKHAF577NvpXGsOQNt8s4NhTM8TLdzT3t7+Ppzq+VFNjz6cMIwEBgSn12ODMIYPHINYYcgRQ2ssMOOA
-uLV3QDSR7PO4MsLTromnXIGWeLBlmmo2y2EkMICDYyDwnevX4xTTpVgdA3n92h0Qb3gd+DtSH+inJpkj
-TQaxZbHGVHQSkfBrGFSAd2R63M7CupWqtCnt4pxwGhOa4M83C6WQEF4MBvDmMGxYj3wLryGQUzbB8xQx
-LIeAyVFCFDI6x15kcuhYJ+oy1GRDwSgejq2pnJ0PP1xOxmC8MQcEHAvIFnZIKlWAyADlefqofqQpLApR
-MGxjdSzxnUkPpByLyCrkG5KmME8xYoDoI+QMP5Cs4PCA0gJzSdA1MtOrzCeaMb/Lip4dXtfMlDLccQ79
-WTSZXPYewj6MsVCzZDK5VET1HNKzxGFbgzvhWXqWsWCELnsPnmd5gIHK4ehykp0WDCnf+OBZkQlkFnmP
-uf1ZLEQKA3g4bgsULZidSbpGYr7CUo8Psfrd2/+f3n8nr8PeHV+vkg19nP5n+B/7hhkpRtljALRI06bV
-PliTpZkAJMeUJJAY6oYdz2wLSgQMIOBBg8rd0dQlYCCrl176AQPpuTi+oKLsf2hHUQpbqNSE9+EwgnUf
-3h9EsOrD2/cHBzYZKe6CJJjCAIp4Ba/g6NuyeWOaE3gFfyxbqdP69qBsfnSb378zHMCrARR3Uoapl9g8
-lJOvTBU8Q7MTzxqcatMu25klbt/fyOoSb+rEVWbTaXxr9AmfDIfnKVr21OSuZWaVQavp41m1nlBzhBYp
-WsIvA+0dXDL7+3AyHM5ORheTi5PhpYxqRJA5SmUzyG5queLCKOupeDqE776DP4bHWv1Onv3SZqPXaI1f
-RnAQSgjKT7KCKm94AGuMKIcko4EAuQzLmIlsWHs1J8OL3c5yWljsBonsjtLUHc5Gzm+6tyT8FrHK+Qua
-4AWhOAlcZZYg8Obw14ywk9XeSTakWRtctYEYajZJHpmRuzKZDo/jOFTjMISBefd9QVIpWTAMjO6Hw+Eu
-GIbDNiTDYYXn8mI41ogEYksstiCToC3YZLNFN3r3duagBItTL2a6MJe9mtjLV0FkNC1zhz7c3QWSQhBB
-NWGnEdwFklIQaS+KBB69eztMCeKTxxzr94ojv59ZMQiGKJfLt345wGAmWqTIRmU6yltmnso+VObDnZzS
-AdCkLYh+qoBqybTpw969nSEpQFjP1usARvRpif8xd1ho5NttKJS712j6FRLr6530P9p7cgb8v26uz3r/
-zCiekSSspmTjVbsrAz8419WwTQOu8IaIkt/8fk76uuAWRd8iMOI6gvveus3IfLctpXnhhhT10jcerQ2U
-ctziae6CYRCBnrIRBCfXw6sz9UM/X32U/08+TuSf28lI/hnfnqs/ox/ln+uhbJ6WGbRh74X2bGVQsC5g
-GSmA7rl60uZRNDflUnpyc3rTEylZh324EMBXWZEmcI8BUcCMZUzqRdGxac+BjAaHR3+Kd5riaNlsVOh2
-ndb/ylk9R0igZTWrl8/MezcqawYt+etifY9ZC5eeSTVjPa8H+2p6KnvZzb0r0JahVRZn0N1ORrshu52M
-mqikIRpEyio1qowlmEU5wwvMMJ3jSIkUyUyAzNUiHH/OnyWoEDZJauuvhY5Sja0G5rxVrJnXenC81xXP
-3TBKmG4KRspuAC1+9/u2cKbf/z7WT1EumNKTBVMP7XCVwixw1dLeQ5u3AVYP7XBGjxbSPLbDapVaUP30
-K2K1M7vGox+1DeeMZIyIx2iDyXIlojxj4lmTHY9+bBqs9tpfZ66Wi25r1OxtseiMbXn777Y1zh6siJX9
-6Oc2WC2shdRPrTgzVkLJ319pC+O/nt9qa0DpUjK1Wkcq7X0moKqOLYYgm7/aFEoWtngmQpeY5YzQLUPe
-ElV/1xHnq0VeymJBy4Z2eEew0nNUTb8qOtvB1YuZgqMljoDjFM9FxiK9r0LoUq9u5pgJsiBzJLAa2Mnl
-uCVVkq1fPayKg+7Rspx1Q7gc/8qJLhM7TxagGCccELzU8C/L7cPf0UJEypHSioVSD61gVjtVkNDPrcCu
-omwHt+0rnER15Gt0esP0Ic3n2srIWS98DuGXX6A6z/lcbjxPPk52S8UmHyctVqhWDLstqK0x1Nj+rdNr
-6VOF3rvHZuONg9iQOe67MABW9YQr0AVhXJgOdcDPwiIywIQm5IEkBUotidjvc30zOevDxUJCMwyIYedA
-4dB0isr9KW4XOxlNHwHN55jzTiYiEKuCAxGQZJjTQEiHIjCDzQoJ2EipJSlCrYg13v6abfADZhHcPypQ
-QpcNDWi+I3XAuJZcYg73aP5pg1hS42yerXMkyD1JZYDdrDBV2FJMe+o4M4TBAA7VsVaPUIGpHGqUpo8h
-3DOMPtXQ3bPsE6aOZjBi6aOURite4KXZ4haYC0fvtV1YZz517YFs31hxASsDGMCdAz3dbaekjdDdwfR5
-Wq2MNTZTrj7W0snn5vbVx+bUVlsCv1UC+e9OAdef29YQHTngTnnb9Y67n9ctm5PX42o9e3U2Phv9eOat
-j53NsBqAuz9UP3SDFwM4DGunRL2XFYbKueSCQ0ZxGXjVcYfEH78Md9+1djfe1aGeW44CT2Ft57piZNZ1
-xOfwak7D4zZVzH6L05efKZ8JkfbhIRaZwRXWNu6qGp3SXmcC3afYqQeZqO23uzTbqPOvFVmu+nAUAcWb
-7xHHfXgrw6N6/a19/U69vrjtw/vp1CJShR0vD+ELHMEXeAtfjuFb+ALv4AvAF3j/sjxuSwnFz53Q1vjd
-dgxP5Bq3Bu+dxksgxS4MgOSx+unvR6umutP1K0w0SB1GnaEY1LN4jXINF1U2SNq6uNVLxfooyUSPhMcN
-sKcw/ikjtBdEQe1tq/N2mbFoNdu1znvNX0ZHcsRLLcmHhp5k47OaUkAdujIkSm3J53+rvgxDjsYU+7vp
-jGUbacklV3mcZpswAqdBTpmwnE9m5jjmqaaDqfvLNkYC+AJB2DbtNbQBOoagTJQvfri+Gek9UMcfu61d
-5xI1N+kXmnm1IJ5/vLi6vRlNZpPR8Hp8fjO60j4mVS5Lz8Ky8EVFljp8M87UIZqpe4NEoHJ3TUb/FiL1
-4/q/MmIHfwmeCb+alWZAxwIZ9isvpQ5xKh+tw3ddwrBJUFV1aGiRNiL97YfRD2c9xwZ0QznKSfw3jPMP
-9BPNNlQyoI9kTNC7mTX6l22dKAQrDIZXr/bgFfwlwTnDcyRwsgev9itUSyzKlKOntc4FYsIrPcmSzuig
-gMsans7yHVWOZut2vJIdZwJIIJfpkdKuLsC71yapZFFVb/CzjspP+r0D2waT5YLHivT07mAKQ5u2SCty
-4a1eBn6Xwync5HrVYc/eMratX2lXYGsoqxosryzLViPBK6uqCfqEu05/Q0DcqZWCIX2sJoku1rrHDi5J
-kOAE7vFCrx0JL+da7JyQrQuBhF7wLskDpi5bnaqRwljbaRGz4ktkCrPG6Zuf72/0dpbEbm1H/laxyZSw
-8N7PTxoicqxrt40E6Xeq3PbrnI/JrDSkVvgKPWBHWJQyjJJHq/p6T4nbDhQgaqpx1ZxyijlNZUjb6q57
-peIGfu1pty5h2xymDZJuvx3j9s4rYidwO+PhWVPLmHSORluuWgJ3uSOvaDRLYFB1UYlqA7BZEZ0lYVdi
-tM4SWybVkhK1VzBvQbe/D7qQX1RWqyaVWeW3dlKleVniOKJvvnG287xXnZSNMA4S75aBh+O4FcNTa2tZ
-oe3EYjXE3fpqZ9DUbp+NRjejPtjw55VuBy0ou+1RJ63GAOqr1/o6R9UwJqa69ecnf31TeQRz8cYdmcbK
-+7sq3Jim+phInGW3S8LlHCv7NERUuXyVwgu8fiaLlyCNDSWtjSZyk9NDPanXw6Hi8etGr8B6TYb/tyAM
-80ZZvHX4rhpaEVURtNeGw1dTC4IwhhuaPsLWztsY2GCGgRfaxQf1XTipUHezbc+byWkqHX5JZm+bI6tr
-o9WRGcs4lTGDqKjqWIa37rbQugKmq1beMdIKp9XGn+GwzZJkTCxolRtJBFY/rc70hYf97nDaUqG0s2k1
-TCzYAuQTPphuxVfubxnJ1B4OImlj1Lf5FXUBofQVd3UG5JrDOf3rtpnSpbTbTIux7FJZ7xYCddfW17ja
-urFX3b1TgzFoGVLnplnjXfMiV9lLpH2vnNkHeaoF7maa2pJOHDe7lEGtBK9Gz+/q3+qJ7ZajuTLYkgEY
-vel3jma9lfwzSzaUJHq100tsfatf8yrXUc5+IllAdVBFVWIYAeK8WGMguUTHMOdxmWQQc9xTyyVb0shG
-3uiljO4lzLlnBW2j33bhT6PrW8H2drADuyfvXeHzLcoou/3mXYLnJMFwjzhOQC5nJKsW/k25zLF38Li+
-g1ctb+QCTT55J9Kq603rvTsJ6929U7C2IO/iHK4+Vpj1kKlxtHLuOckeb71y5+fFz0aStU6G20PClkuB
-1eVAhufti4att/a+OttVwnfmuTtkueuu/HZrdtvMbN2stnbp8FeCdea884zyLMVxmi17rbJU1xivOu8v
-BlF7hDW3GNvfBr3xJ5LnhC5fhEED4pm92ae9dv/oXxtmeG43vUgO1d3lMspwWLBsDSsh8v7+Phdo/il7
-wGyRZpt4nq330f6fDg/e/fHbg/3Do8P37w8kpgeCbIef0APic0ZyEaP7rBCqT0ruGWKP+/cpyY3dxSux
-dvZrb3tJ5m2HyYiWZCLmeUpEL4htFry/DznDQhDM3ugtW1e6nvr3Ork7mIbwCo7evQ/hNciGw2lYazlq
-tLydhrUb1XZzvFi7x1i0WKvbJeXlkpaK7yCoX3t0Dr8kvpY+tFg3LpBrvw9/kHy27Ay+lT7nz8r1vHnj
-XXGRPMIVEqt4kWYZU0zvK2krM/Kww2sI4gBeQ9Kya5iUxeRpViSLFDEMqrYe874+3MZCXY0U6khc8ugU
-X5SnhKoS+Xx2O7r5+I/Zzfm5qsyflyhnOcs+P/YhyBaLAJ6O5WjfyiZICEf3KU7qKK47MVAfAaZt/c8/
-XF52YVgUaerheD1CJF0WtMIl32D2xl5mdlXQ36t4NxfWssVCB0MqSHkvFHrOnbaw77Nn7np2ampm+lUa
-a6FKm0S7yFw/S4VaIh8okZ4DpePxZbtkJZEP1xc/no3Gw8vx+LJNlMKi4jz1JfGJ0J1pXD9HQouh7PnD
-eHJzFcHt6ObHi9OzEYxvz04uzi9OYHR2cjM6hck/bs/Gjk+Y2Wsh1UwY4YQwGWz/tZdDVIfyZkcQBaHy
-OuZihxF8dHZ6MTo7aSkCc15uKRnhWcF0hXq3XF6NSIK5IFQt0nbq9fueQ2lxpCuLpCvTZ1MVx/6pkVHh
-5OzqdrsePYj/V2anMj+MLpv6+zC6lMHbvH97cNgK8vbg0EKdj1qvqqhmW5Ezvj2fff/h4lLOWIE+YV5t
-8yvPmyMmeB8m+vMNgkOmavxkP5vp90QG9xh+ymQE1yuMAIJQeXV1CKy7n16P9WN52ThnZI3Yo4Mrhl7l
-I/8SqMuxDG368HdVVtjbrMh8pbGEOsvOmDqYKChKBWY4AZuGOXzaUKI4UqsxyY8ga6xYkSsyXWiHGWTM
-pO4uKzQT9pAjgoITunTuRSsmVXZl8OJ1niKhcaMkIeYkzn7vQmtrrj6Ukbjyzni++EOihV6kSAhM+zCE
-lHD9nQT9+QPT3wDI4Fm5VGcwW1yodoN6FH/5BZzHal/3qHnvPnBNpNwNRQJSjLiAI8ApVtsvjUTNUDTD
-5e5Gl83u9Gl0ZGjT7MbQRnaaMbTh+aLsqv293r1WZUkrXGrO0byOCHrHINf74BZaZh3OoZa0Lqyivlr0
-ygxj8nFSHTVKcooFux9mVGlKK4KwRFzZpm+MNg2/WNjRlIZFuFIy5kIa2xJTzPQXVSrqzioebWpIrQo1
-SwavXGV6DdX+6IH36ZOyw6AG31IXU1ERIm3eOVWrpsnHSa8ctsgoLNLfsCi7huGzN1C7kYXNj+64irUr
-LqlWnuO59OVJZBJPPWul4up6s9185SjwUjUW5rhG9YftQ+abWZ1wTZUNydWkqRSZd+myocdnMVXVQt4q
-1/0gwrY4sdXRnwyHWxw8yRK80F3nGRVoLuR0S6utvl5mqhkq8NncfJKhD99nWYoRVXv4mCZyDjGsLiuZ
-qUQYTvYtfCytQvrzcofBu5HiXMJleFFwnDTIc17gPlwa33Iy5KCjkl7JpdkGJ9J5KDgXNa99ZAN6Ogbo
-0lRjJnaPT0dPhWND0qQPQ4O5ojeXMisiEmKOWNJGjXD7TY/t9Jwo4gx1ZxTZ3afXDFxzXPoj/TgYQEAz
-ioOwhs+8hjt4efwSpsdtyKT0NYSqaTtSDVIhLjGXIpacvqh1U3dNelvksd51MJDu9ZtvdmHX6xNCSxh2
-Z2AzDMsxxVSwR9mkmcpYZUBfGyfrCpdzr/4ZAudVOS074sHJcOi7n5eq28sIHCSR92WVXaPDTqg7o0XN
-psKOjekIUic4uoOtt6xTTPVW9Y4cSgQVh/LpjkzD8Hivy9B/BWOOVX09cxKJz6BscZmsB4qxCpIITv92
-cWUv15QfCPzz0btv4f5RYO9rb3+7uOohVn7eYr4q6Kcx+aec+Efv3lXfWRp1Fn1b8RFjLSLD60GFtJJ+
-ZI8PWcxTMsc9EklYB9Tf8R1JEf8vAAD//1RgxiUaVQAA
+H4sIAAAAAAAC/+x9a3cbN7Lgd/2Kss5OmrRarYdjzz1UGIdjy7na0etQcsZ3FS0vxAYpxM1uDoCWrHGU
+376n8OgGutEU7Ztkvqw+2CRQKBQKhXrhwagUFITkbCqjg42NO8JhWuQzGMLnDQAATudMSE64GMDVdazK
+0lxMlry4Yyn1iosFYXmrYJKTBTWlj6aLlM5ImckRnwsYwtX1wcbGzg68VfAg6WKZEUkFpHSaEU5TuGfy
+Ft6enYyOTieXhyfnx6PLwxhuHgBxJwpl3WoIn7GfWZlPJStyYDmTjGTsX7TXN6Pyhtg1zBVDDQ738UD9
+1x4bALTIe3QIPKX3Y9t/D0cUg3xY0hgWVBJLMptBD0v7DtX4HYZDiE5Gp+9Hx5Hu6lH9izzhdI7dKS4N
+oMY8cPAP1L+WeGRMUjMjWZbitsfpvH9gpEGWPFeYWkN4m4tzw6knB1HMVDEMkfji5hc6lRF88w1EbDmZ
+Fvkd5YIVuYiA5V57/MPviQ8HQ5gVfEHkRMpeoL7fZEwqll/DGE8aNG9Ssezgzc4OLAkXVEv1KVlQEMuM
+SQGb9BNZLDOaTIvFsztG7zeB5bIArMPmQPIUsDypGdxApThsuYIjWhIukQ9qQah+etGzqH9QsV0BJBnN
+5/IWvod9+PVX3ehq91rPQ1QX7dkiT9pueXEP0VF+RzKWGvnXBEewBf/74uw0QU2Sz9nswRC4BdEAUMW4
+Y96EggeY4Imv4aadIktprPgyAG80SOo+vK5pH+BgGossp/eafUY4KyFvcFHQFIZhdnvzXPOlolDQNFHI
+q6qKWKzCL3Fb4wzqj7En5AP4/OjCTwuettXTea2dXHCjhS4vjwewG3u0CsrvWtqMzfOC03SSkRuaNeok
+mftqzuXrkhdTKsRbwueit4iNWFim7uzgygRKprewKFI2Y5THwGbAJDABJEmSCs5gHMCUZBkCKK2v8Vkg
+wjl5GNhOkSklF+yOZg8WQmsTXLx8TlU3uLSQnymRpFoOk4SJd6bH3qLvKZieGYORR6CZoFWjEVLQaIFD
+7KH8/KIUlluFfz6Lrn65jsHroZb6Rl9naiyNziYJ/SRpnhoqExxaDAuf2taq/cdofHp0+uPA9FxNhrYh
+ZS7K5bLgkqYDXMwe+VZhN4ojY6/bDQxhWhPqwWl9+FavvXrpDeANp0RSIPD29MIgTOC9oCBvKS4csqCS
+cgFE2BWgtOOiSIWjHd+uWtRmxMMVKuBgw5tGBkPYPQAG37nW3KibA2BbW+6EeNPrwF+x5kQ/trvZ190Q
+Pi8XNJednSD8AoY14BW7PgiTsAj2ijKljZjjlSUsT+mns5nR1s+GQ9jea+t8rEVVDszxyRYFx1kiORT5
+lHrK2+nHmkmXoDYZCsboWCMqk8MPl4enemL7AxilaUsAQBZA7PTKWyLhngggGackfWg6j71+gniPZno0
+qHxAlDemdTEDUrcwhT2azBPYlJQsEtd89WPEpDWlIobTjEh2R0ESPqdSAOEUFiSlTk2hKay6dKXXG+sX
+miNPwmcsT42Iu/bItUCOS2CbDYdQ5imdsZymAYNvyVMLvZaFRSkk3NA2n+GGzgqudO+U5AiiFRZNWw7q
+7J9pboeXOgoD60R5A0MDMRy6SgVeQxTBQNUlImNT2tuNYbvngJhlBFuw13cYNWNcyLESI6hQGrGyK88H
+PlJ2sQb27aTXBqW2srq9PhR59gBkucwYFXr+aSXCSmCZUKYuqeexal53WJcFlNTemtrD0w+uCgmqilbP
+SE2DDJQfNUMtN1H7R50Kz5kCo2GDsxDSf7w1Z54S5Fo4hvUaO8ZJ6nGzCER54xhcbWOnRS5RS/WuotHx
+0egiiiF6czo6OcQPJx/w31NVeDH+KbqOgScqBmtaeJ7ole92fqlKerYqVuLatvgdHNJy53EoLHpNRgWB
+r9h1my9dkC6nrOWuFYuZX6HEWaOoVQAxAUyP5cr37cdQcO2RKHADhxhNUwOolSPTWKdZIaiQqBZoLqGY
+mTC/UpiOmtNTq/pyFOesKPO026h79mmFwKUw9GA9cVMaVKlUZTddEuwfShfLS9qc9Lq5ltihjtuazTWn
+Ie1qrmM9pf+2DS5P7+nFmaDW1rXwzTdeBz3FJ98AYBDo4/pes9Mtay0Ag6hNqxfNmWnRIuVLI+hwHW2n
+tqye6URNo/z4IvdtKpPCiknLrDbEXWHVsg2fXapUhebVDxG8Vp0NTOmW4Z8ob1p06wUO/yxJhq40Ut5w
+BKC3+YMKdgkIls8zqrH2talEUa+QaWMWIN6oEU+JONmUZxgnXKio28D028rYkNN0B63O0iMPNMPONAvC
+TSv3MUoiLW3bewE0ph/LyzDWBqXGC/RTfma1z/L+AN5qXQKkSq2ppf7+4rACVw7fTLlCZCH6nuZq+JIq
+FtIOZZpaY9121LrI6ZgQvah//RWqZR7MqDTQDlTnqon1sQjkRb5NF0v5ADrF0pqRZ15EO8v763TkOnS6
+24qZddd2+K0uJ8ktET3bQsRaiX1pv+sGFVU/V9gS7dkst3Liznqvcnlx0nXk0D3jyvFv0KfkZmzBlSvP
+P1oH12OSDiXQl6OMw6Yt36zyDY7wdNPoS8+aPHXRDaDMP+bFfZ079zncM/ytkiqNAWuXo99yz1WQNWxy
+3qwnlOvPj35SzA62lzZNqeoBhpAG3e12DKt69uzSa7jCzB7WxJA6jlTbeTJu01M+pedXBl1K49b/ndKl
+mniW55QvCiFrRrNZ9VkLS06FpGni4cCp5SpTk1QNO8Mu+9dqAHWA1HYIHp3kHGrOw3ej98eXF2Dy1Src
+pcqZMq58HTUotbdcZg/qQ5bBrJQlt/pPqOVwiBk8lZiTRY38nmUZTDNKOJD8AZac3rGiFHBHspIK7NDV
+nqZVtQsT3ikJOWxPBjgOKp1McIOchi+L0dldfwAXVKpJxQBnVnCTg9Ji4JCtwZ016ij4O29l3sHQaOfL
+4m3JiVoJd/2AlavWCXfb80TKDIZwdxBKtAYwOx7vgsjprdpdukvU597O/+39nG71e1dicZve5w/Xr/v/
+a8eJ/qsWQ8jLLGurmDurnfNCAgGT9Te9h8wQ0lHmTMIQIhG1errav3Y7MZB1pYfKZgWOclm137t2QvlS
+beCIAezFsBjAq90Ybgfw4tXurt2yKa+iNEI7USa38Bz2v62K701xCs/hr1Vp7pS+2K2KH9ziVy8NBfB8
+COUVjuHa04B31QKsUwGusNnFZ4Uu7Ge4bf8gyfNUTuoH+2EBXJCP9M1o9C4j855a4A0/uhZqtYR8R1At
+qikhs4zM4deh1hAHvs56MxpN3oyPLo/ejI4xM8wkm5IMiwGbqW1eFwaGHk178N138Ne+2Ut2diM37Z4d
+5s42Y9jtI0Qu3hSlDi93YUFJLiAt8kiq7aqCm+ww1ZrN2TdJ3Ma4NCx2gwSbq6xOPZ2tnVHTPLAtamq0
+s1iZB89rrEBge+9LZrimonahDK7GRIw0mWwZm5k7sf5SkiR9NQ8jGJq6v5Usw5FFo8jwfjQarYNhNAoh
+GY1qPJiR0Yhs+NOJDEED2LDYohu/fDFxUILFqbd8uzBXrdrYq6ooNpxG32UAV1cR9hDFUC/Y6xiuIuwp
+irUmJZKOX74YZYyIy4cl1fWKIr+d2XWTnOQCN7kH1QSDWWix6jaundrAyjOZMQRMfC/CAFT5KwXixouu
+8jEbUqYNf/liQnAA7XxYA8AM/brC/7B0kyLNPasQCqXuNZpBjcTqescDijcenQn/P2enh71/FTmdsLRf
+L8lWVViVgW+gm2xYxQF38KYTNX7z+anRNwduUQwsgg7Xr6I8JGS+2m5Gq7oylAYgmaABTXMVjTA1qpas
+mzd9MxrV6dPLD5f43/nlGP+7OH9ns6mYWh1h8XWVRjDkPdOarTIKVgXMYwXQvVbfhDSKpqbajr48e3vW
+kxlb9AdwJEHcFmWWqkA3B8p5wZEvqh/r+uxCwWFv/z+StZY4mbcLFbp1l/XvuaqnhEgyr1f1/Il171pl
+TaDt/rRc3FAeoNITqbatF01jXy9PJS/rqXcFGphaJXEG3fnleD1k55fjNioURIPodFShKnhKebzkdEY5
+zac0VkOK0RNgU7WRTT8tn+zwdBTsUkt/w3RUbAwKmFOrSDPVenK86prmbhg1mO4ezCi7AfTwu+tD5kzX
+/znSn5Ol5IpPFkx9CcPVDLPAdUm4hRZvA6y+hOEMHy2k+RqG1Sy1oPrbF9hqZ3VdjH/SMrzkrOBMPsT3
+lM1vZYzHPJ4U2YvxT22B1Vr768TVUtEtjZq8FRJd8BW1/25ZE/zODrGWH/09BKsHayH1tyDOgldQ+Pkr
+ZeHiP9+da2kg2RyJul3Eyu19wqCqhgFBwOKvFoWKhBWaieVzypec5SumPGBV/9QZF7ezZTUWC1oVhOGd
+gVWaoy76IutsJ1dNK5SCzGkMgmZ0Kgse67wKy+dqmmFKuWQzNiWSqom9PL4IuEpY+tXTqijoni1LWTeE
+S/EXLnR07LyxQE5pKoDApobfrFKIf6KEyEwQxRULpb4EwSx3aiOhvweBXUbZBm7ZVyiJ+rC84ekZ1wcd
+PzUiIyde+KS2tuozkZ+qw1uXHy7Xc8UuP1wGpFBFDOsF1FYYGmT/0e416lSpt6qoSbwJkPdsSgcuDIBl
+vTnZoHdBdIMm4CdpERlglqfsjqUlyWwXid/m9Ay3fo5mCM2p3qmq9s/2TKO4yk8JG+zog0lT3G3pJCIG
+eVsKYBLSgoo8kqhQJOVwr87Z4aixK5bbITZo+8/int5Rri5pICjL5y0OaLpj7IQtkEoq4IZMP94TnjYo
+mxaLJZHshmVoYO9vqdpzg4zmPXUkGHefYU/t7fVYLmmOU02y7KEPN5ySjw10N7z4SHOHM5Tw7MHs5AEi
+mJs0t6RCOnxvZGGd9dSVA1mdWHEBawEYwpUDfb1epiTU0dXu9dN9BQlrJVNOPjTcyafW9smH9tJWKYE/
+yoH8d7uAi0+hGKLDB1zLbztdM/t5GkhOnl7U8ezJ4cXh+KdDLz52kmENADc/1Nx4w9zMXr+xU9TbrDHU
+ymUpBRQ5rQyv2u5A/Mlmf/2stZt4Vxt77qUdsNvPVea6JmTStc1Xg4DdHw6xYvJH7L58zsVEymwAd4ks
+DK5+I3FX32Sq5HUiyU1GnTsVlyr9dpUV92r/65bNbwewH0NO7/9GBB3ACzSPqvpbW/1SVR+dD+DV9bVF
+pA5Vbe7Bb7APv8EL+O0AvoXf4CX8BvAbvNqsttsyltOndmkb9K46WceWMGzCezvvCKTIhSGwZaI++vlo
+VdRUuv4tDQ3ShME/i3qSLMhSw8W1DLJQE2ca83Kxnxayx/oHLbDHfvJLwfJeFEeN2qDydomxaDXZqzf6
+HR7hjFdcwi8tPmHhk5xSQB28Ml1U3MLv/1Z+GYIcjiny1+MZbm4P4aqiaplkBR5VdQpwyfSr9WRWjiOe
+ajmY25HFvRkB/AZRP7TsNbQBOoCocpSPfjw9G+scqKOP3dKufYmGmmycDXbvU3j68XL040UPM+mAxmMA
+IynxYAfe8PJPvcX4VUch1amh7W2Ec49GIDb3FILUGbEJpsOkVM6ZsR8rrtgopCt0hYShgmmdvvXOPqpg
+RDo3GZ3TiXFk77aoiurG47OoKbjmcBUObADM3HSUZB664SjN9UY1aHtcrnlOTyjWFaVEF3ZBRKy2FIQk
+XKIHjJXwLFp9ajZoGr+Uk/WZYwVleWMYGzq9WUuXaqFkyoKveQrp6OT8bHw5uRyPTi/enY1PtEnLlIXU
+Sr+6gKAcmSZ8261pQrQjxVYXkQoVdTf6s5SZ70b+ng5i9EP0hLenSWkB4Wmvq6iiwRLvXW5W7Vsj7Lc7
+VAeJNLTMWo7l+fvxj4c9R+XogkrA0gSPvL03hwqHdgfQ+Fhnk1b7qqwTheSlwfD8+QY8hx9SuuQUE1Lp
+BjzfqVHNqaw83J7mulou3mmnIu10RhRwdWyscz0giuqomHdKzFl6COQSba4OqcTLjRZJNRZ1IhI+6yX/
+qOsd2BBMsZQiUV1foz4aWS8ZpciFt3wZ+k32ruFsqYNcu9Vb8FXtKrkCe0i4PvbnnQS0B+DguWXVJflI
+uw4b9IGIun0Co/yhqhP6fOANdXDp+1CpvSOmLkFZUhNnQ3ZRSiKpMkhzdkdzl6xO1uBgrOwEhumd866P
+BPvi5+sbnT1F7FZ28LMycebElOh9ftQQsSNd6+WtUO9UTb5S+RhHXkNqht+SO1oDVxciDeubLRG3nSgg
+ublArdaUc//WHEQKJRO6A2PXz9SadmXGJKQwrU/mtlvTTVw7AeOYL2c+PGkKzEnnbIQscwW8yjw72g2G
+dRPl97QA25fYi7Tf5YcvitTQHfLAw5fOV6Db2bHXdWqpVYvK+DzBRoh/UaSOIvrmGyd77FV19mwGU0P6
+T394OA6CGB6DpdWlescWqynu5leYQONAHo7HZ+MBWPPn3baPAii75VH91zcC0PQIm66yvkhrDlR/fvTD
+6VojmCd33JlpJXq+q82NKQodna+aHTN1vL9q0xqiCh3riFHSxRNBI4K08peaG23kJoSEZgyppwO53nij
+AP8iqzU5/WfJOBUQBaCabAgiqvgAvRAOn00BBP0EzjBxtrLxKgLuKacgSq3io4PAPUI3xtjwVnKmoryq
+m5UhRpMbnZcqCJ+/RZvBcL5dyWhdsEBofeCq63kDR0hrnPX1xGDUgjaxzGvfCBFY/gSV6TMP+9XedeBA
+3Nqi1RKxaAWQ3/Hu9Up8lkN2ZCplSFjWmvVVegX/al1x1SQAYw5ns7lbZiqVEpaZgLCsc5kDnHNn3dc5
+2lSN6YKik4H7Q2ZvzrpFRMA9Z5iSiBWJ+qTfggrckXX2d5ByUZRcZfD1h+NiqjO6fUdlrgzNLSLT+TAg
+QM5jU6269ts/VStMHbtn9X2Qx4ab0HaKA87LQbtJZUIr8FpWupviGtIcW+HX4dgSDTXJDGMrTje9sg0/
+C2Gz9eZNsoA3Y2ZF1zlS0kiC+fNqWgnYnLGMDjBXtwnFrHHfC68+UQ5FKQVLKRQzRHRLsyWK6C8ihoI7
+d9XYzOwMk+lHmJI8UrkhzUX3km9DwJwIVzdVb9bAIQprr5+osoPg2V1VFTq7W9HUvKhjE/qqpX0x7Oc8
+WpGoU02eeqBGAV2x6+oW0s/iOZHQez1Inv/c67/uJVv9gbqZNPg53eq9HvyMX37u91//3H9dXVCqHEZ0
+EhcY7WL+LqoZHvkVOEwupgWnWNdxkV6BY8ougi1YVDeOOtJuDufWyFqQNNUBfy+1Nwr8WwaYSnB2cNjM
+1AATGOTcUB4DEaJcUGBLRMepEEnlZzOzwd4IpwKRVCt08qIm93HAqaeaQirJeR+vgX9gB7axhnKyu6De
+U2S+mns8WPFeWEqnuOhuiKApFLkm1cJvw7vGy2GivqlrVDDRJyq8M0Cq6VnwtTCE9V4MU7D2CPTRO9zb
+rjDrKVPzaMe54cQ74qmkLcI85UwtdDwY9opWPGVm/5QmD8fNK98a++qATw2+M9RbI9BbdIV4KwO8x41V
+gV3jqbQvBOsM+6ZFLgrc7izmveBY6sfXTjpfXYviYFP79lq4NupdfGTLJcvnz/pRC+KpJP3GGg5Pt2G3
+jHrCmj9uhI23f2+c06k1zmwJ9cudlYMlYMaLBdxKuRzs7CibVdxRPsuKe3ySa4fs/Mfe7su/fru7s7e/
+9+rVLmK6Y8Q2+IXcETHlbCkTclOUUrXJ2A0n/GHnJmNLI93JrVw4+3DnvbTw8s761ShpzWVirSW+9cmp
+lIzybb0V546up/620qvd6z5eRH35qg9bgAV71/1GyX6r5MV1v/GSpd30LBfu8YS8XHTfKTeURIE3HOrX
+KspFyIPIy0Xr+VRtXeAvSGfAX3hxAAy+Vwpue9tFqWiEEyJvk1lWFFwRvaNGWwurh716NSQNpOert2Te
+ZEWZzvR7IHjZiYqBKj+hUl17x702oWh0DtVVpz/UDZN3k/Px2Yf/mpy9e4dmEaYVSnzy9dPDAKJiNsNn
+RXG2z7EIUiZw+yVtojjtxJD7CGgeav/u/fFxF4ZZmWUejq0xYdm8zGtcWEP5tn3o0WXBYKOmXdtpKGYz
+bXJzyao7/9Bz7ir3Bz555h5/J6cmpl3NsUCvebvTrm5On+wlt528zxlqDpJdXByHR1Z18v706KfD8cXo
++OLiODSU0qISIvNH4neSr93H6VNd6GEoeX5/cXl2EsP5+Oyno7eHY7g4P3xz9O7oDYwP35yN38Llf50f
+Xjg6YWKv+9UrYUxTxtGk/76X/lQDf2tdbx2rtWgGPj58ezQ+fBM43OtUrjgKqC1IFK8al3f2L6VCslwZ
+oLVa/bkbvno4qMpiVGWqzKHY3541LMS3WVbz0YP4/8zsZOb78XGbf+/Hx2i8Tf2L3b0gyIvdPQv1bhy8
+gqiK7UnLi/N3k7+9PzrGFSvJRyrq/bSBeQ9cigFc6qdtpbBphovzdwYv9GQBNxQwn21fHIowPVy9OKqb
+40sl6mv1iMSSswXhDw6uBHq1jvwhUo8ecHI/gH+o9Fjv/pZNbzWWvvblC06R4jInmaScpmDdMIdOa0oU
+RVIaeiRb6Pe3MO7TB6gph4KbAMElJS+k3U2MocR30Zz3LhSRyrsyeN23vUiaMrPlbWw3aG5N1SPCqTve
+iVjO/pLqQZvDSAMYQcaEfgNHP21j2hsANJ61SnUmM6BCVUmiZ/HXX8H5Wm+g7AeebXKw1tsOREJGiZCw
+DzSjKs/Zft9Ld2Gmy827VMXu8mk15OS+3YyTe2w04eReLGdV0zpHoLeK1JHTW1pxz+G+tgr1E6ZLvfFk
+W6D34ewiy0I/QqTP2eMUqCsg1d6+7VaRA0OPreb4XNSvkNdy6gumdcmPZnZmUciYUAxXrzPFMKc55frl
+6ZoCJ29A7htILTs1SQYvxrVeQb0psetye1k1GDbgA2cf616kzNrvCqgICm/YVFMYG4bF+q2iqmm//+Qr
+A93I+oGnSh3G2ugLmACxpFPU62lsnFC9gpFxTb7ZZj5zFHjFGgtz0Oj1x9VT5otas+MGK1sjN69DWkYu
+u3jZ4uOTmPp9byA24nUfvVllM1YqfXzwoFvZsyKlM91UvXOLGzaEZXVysVeYI0Q1+GRqnt0ZwN+KIqMk
+VxtnNE9xDXGqLqSapcQ4TXcsfIJSoc422pyGd+vQeWiB01mJefBm90KUdADHRse8GQnQFkpHdVlxT1OQ
+hYZzUYvGQ0rQ0/ZAmNdClZjYrKK2pArHPcvSAYwM5rq/Kck1AJ6KSaeEp6HemDDdJav7cyyKM9WdFmV9
+/d4QcE1xpY/0V0yN50VOo34Dn6mGK9g82ITrgxAyHH0DoSpajVSD1IgrzNUQK0qfNZqpI7y9FeOpf/AD
+1es336xDrtemDwGT7K7AtknGOaW55A+gHihGogpeC9D/xGY2mY7rr/ncjFNVLc0Om4AvpXgqaFM124zB
+QRJ7L2itayHWQt1pMRpy1e9Ih8eQOQbSnXCz/URznSBfk0JEUFOI33DzuH+w0SXsX0CYI1lfTxwi8QnE
+EpfIprG4UIaSwNu/H50Y17r+MZXv919+CzcPknq/jPH3o5Me4d4PYkxvy/zjBfsXhSHsv3xZv6k37rzg
+Y1lAOA8MG7aGNdKaA2O7d8/NK9UsRlgH1M8Cj+0wf6J5WlT754JKFW1smBVlNgHhhqL/seRFWk6p98Sr
+ekz8gpALuFOYUFVP60to6rVWxGae4hAJhkQ5EAMO01uSz6kAJuuWKriMgagdWScuYlJs1Ocvhd7QI2lK
+U9C3cvPUoP1JNxIIcFMulmgC1bOaekBQCoqkU231JRWy6qfMMyoEfDbfB3D6iFjUydhEMYU7z9VKMp+b
+4BFxmUHZDa3YmLmMSgH/neYCfQNeZDC9pdOP/w33hOegsuRQlDJVgZXpF31rdVrHH4/dRZwRIdHBwDtp
+qmBeFPOMTu4L/lEsyZTiHTVVsdA5ywpwwaa8EMVMTl68eqlKTSJM96TSuO7rzXYs9cE6M68VH4pZNZ3O
+aqjR9fRH/2yv2hfXnB82Bnmlv17Xi8XOjTnmUn1tPFpr8A08qMYmvtnMNZXKDJrP8BfYUzvcu27hd7Dn
+fv3edBJ4wlNPvf4VLnMW0baKYKv6rB4J9qq1JO2BLBSgGcUWRP3gz3TVnoDqcWD+jxvlk0qAI6f7FpTu
+zQDpL7F/hEMDXqpb3KgHl9ptNv4k4EVc9knvGaEYcO286dPgH2neEgmFqafqYtO6cfVKt7N5TwsyVFPz
+2tSK8kZIbiqrU5MDXeuTfmzeuK9lWrt4Sm6NMkEx4t64Qr8e4+Bz3rj3f4ZLSVpVirJTf+l+/l1hC11n
+az+Yb3AFns23l13cwVdva6ep/xo79y+hQZpU73DjdQtZ3fx3f0FC2KejaaqEVgcp+EVf/MUTKTaDpfs3
+GrfFRNNXj8eQxuDvearLlp0PWHuPV/P6ferw3qlnW/MveK3afaV63bNUj97jxz+enf14fDj5x9n47xfn
+ozeHk5MPvc9GbMxyfOzrmPHkg6tNf1S6HP5hdbnDvBDOwJUJ9Z96NLxWovpXIF29HDWNRmR09IGjopX8
+qWITGf36ax0aqbMHyBtf52iB3QsJ+pXH5JMPdh3txRARsVx8SrJEk4Ubx0lkftQy7mj2Eptlci/5+rb7
+X9N2b1c3fvE/afzteo2vQ+9B+pwTC7kMIbk+6JDFpiDGoDIorvqOIf3IFvrfiX0k57E/sL7hyYcY83Yx
+CCap11I5Yspv/jqh/ndKtA0rOxdaLfgKAyZP0WlQe3gdaS/D6uhuKJazPWD5NCtTOsC8uTNp8BvJMjtx
+rd+Tsy5NxeQn+tJ4t3Futt1mQ+0M1Ia4hXhl4/5K+lBUmgcVneeWKphKnBTrdW/RQWg4rsWtGqHJm2iT
+9JHiKyU6kxPrCKymJERrM8w7efHqZe+zpDnJZQyLTxPjmKxaF5KSBW6xpQujvxHR4ScdyuDlApZ73vGJ
+dbnxUfMEdGf26SG1GadNJqLRlZGwP6VcnxJQFUmRVw48Ck0/qWi2G/72yp1jt1Uf5ic+pQBOlxmZ0hRu
+HiAl4taLYhVD1liAzd1txXZNpPKqne/dP8eCndWJIP17H6qFdsPav5LRXvFePNNe7k8cuHryxzQ0a43s
+Vqyuf7jJ8LK383OyM48h2na3F6ocVdMQRD9EMUb6GhvKM4ZpyZIXkipCkqKUWVF8XGFP9JuuESllkTIx
+xVQ+3spxv6+Pxa6tPX9h1eXbqDdqcmso5Xy6k43VDSldr+/9jr73f+++G28brNbjDYWkZq6pyVGRh+cO
+tttKHVq/LrZSuQcJOLlYS5EjWH+t3pVO68p2mnliSzUtbJkynhRKzSXZQz712Nw/WIECoV1hvac3X44L
+n1uNJoItk4nMRIT+1K5yg7799sXX0lfhnNGU6meFZCaSiZwunQ5e7r7a0z3MaLpODwFGL9LFajbTXKo3
+KgW1P5prTod01CT3LE+Le5HkVK43CzUemvMiyzDn5uOvy7fxvcOczGkSXlXBwa4I8Rr3Q96NLi5PRkfH
+vc+uwa3DIuViBv3JdyYD5piuCtnX+482r/b7G5JuO6DCAZbvbSsvXl+UYvmc5nMUr5UBhWq/r9vvf0n7
+P0QFBrqG10/pv8CPrOpTrVtbHTQYKZ4tUAOytjfo1Ti/yIhf0S9MZovbQv1w1O8oyCh3P74/7X3GpanE
+2OZjmr5kIKqKQXIy/YiPVdTxlZdePTEnT+3usN0P1b0BExCVIqo2pvtQcIhoGSXezyhan7PKf+EXH6Xz
+i/F5oZM6/1JPyy3pJ9dLNMP9+pVm8tLthWaGZFwu8w3jhNL9oSNTrm8k6StKbhEtg+6mprpO0JomKCTm
+4xZsQq8UVGM1XOxvNh1RFCAYVrSaLvHXkmmZmKElBZ8nEQwg8go6QlEvZdgKR2vN0UgjLD4RJeNIUGuV
+t2BvwrDXv19Q6wx1jYD26wJG1HN/UrhY+0dmga7ki9ZMLhERNdbE9unwvjMu/X8DAC0JQr8piAAA
+`,
+	},
+
+	"/types-dnscontrol.d.ts": {
+		local:   "pkg/js/types-dnscontrol.d.ts",
+		size:    20430,
+		modtime: 0,
+		compressed: `
+H4sIAAAAAAAC/+x8bXMaObb/+3yK8/e+CMwfN0682dpi7myWsXHGNRi7AGe915Wi5e4DKG6kXkltm5nM
+d791JPUDmHZCcKbm1p13dOuhpd950HkS7TaMlylCjFHCFDNcCg1TqSAWOpJiymfBR92ybzDmRiqI5CJN
+kDoCEzEYGh3NMbrlYha8aLfhHQpUzGAMN0u4yXgSt2f+VZt662AmYarkAmIZ6fZkmonIfZfmS29n7Y+6
+PcckRaWDjzqA43MYnI/hp+7gGHrHp+P/96Ldpg8NcYoKRYRg5lzDlCcIzICZIxiZgpyubKJDQwDa7Tb8
+lypGpszMf9izy9p3vY2SSRAHRu9B+x8vXrS/+w7O0LCYGWZhYBDLBeMCpAKFkVRxC3QWzYFpODqZXAzP
+r/49OR8E8F37hUMVgQuDasoiLKf69QUAwPUtLjugjeJi9iH/8f2L39x3uxBnjibABWiMpIh1C+wiXNfi
+y3tvFnst2Hs136PmvVfx3soCLJWO88l+AJEtblDBp+KL7nsCmJplCxSG0DtuNFtwPOldjXuD40bTkue4
+d9K97I9HjWYHWLH/AhONxnAxa8HC79OvNuHaTmnmuNiwMDv4TMZ8ylHBD9BouPk6wMSyCT/8A+4kj5vw
+qcTv09qo6w+bN5EvEnI2AzY1qIAb4pgHjCFlii3QoNIlIcfjfuPw4KD5eK1DO9vKWt0H6tfqFzaeI9yx
+JEMNM36HAu65mcP+/h1TFlr7a5+4eOWrkRTawFH/dPK+Oxx14FdQyGIpkuVm7oHf3PdeADEQi2OSK+jm
+MIxlQa3ALkmwBYKeyyyJ4Qat9ChMmOF3CAm7wcRyvXtNMwRwqRHCf4bFe097luJD8ALsh/+pEWFuTKo7
+7bY2LLrFh2jOxAyDGTfz7Cbgsl3KW/uj/kv3RXXXBbW6DVpgvrkWbUih1vkL+OR5uQVBECw8WXRnjU7X
+H5qdNYapoNTtVoCihz88Vt1uHVzd7mcQ2wWo/ml3BFwDgzuuTMaSHCkrGmbODKSSC6NLsWMGmJBmjqrA
+5NTYOQRL5ExmGgzBfDTonvVacJPZxkxnLEmWoFDL5A7tNAr/k6E2+4Yv0AqMRmWbVhg8gEuR8Ft0E+qW
+X7Rr1BAxQZTLtJuTSPKLFGgJAo3wn2FzB6rQl2rIQk1rdDFMzdA8A1mOCva1P3OS/FGZ96iOd48esa5h
+sw7sca0z3INP/tc9T2L3JGOc7rWcUn0GHMOjbnfy4+Vp/7g3DCFSyAxqu/ESVu24nCWJvAerhKmdjjeM
+IUJl+JRHzCDNxzIzl4objpbH7eKrfXQAI0S4ptl/JDsJ1YfGFlhGjO3fuHHN3eiR77qWLnmHhkzplaZj
+yPLM2xJ3S47150lEAESMOt5ImSATLYdE0fP6AxFTSIF7LSgovKkZfntaCiZHw9Px6VG334Ej/1l6DdOE
+zV5sOFNXRqwySjnvhZIPS4hxyrLEgJxOnTEsDFeFRDSsdLguzc6mT+WGobefJucnJ501A2HDx8Tjb33Z
+7IPayf//kPFklglAwW4SjIOn5ju57PefWCbXn5/iyY1+yRo27yU8OpkMe8enw97ROATuxFSThmPaNo57
+ZxeVHnSu5F6IBkZTkJTA4cErUBhzhZGBRopqwQQKU7xrAhfaIIudLWlwkUrF1JImyPvksndO6kBnaSqV
+936O+ueXxyf97rDXvTjdRUTLzdaIaNmhoWWmooo+jFEbLqzl/yxK8hG2mUYNR4nM4imtal+nGJGKgyky
+kynU0NhL2QxBZQnqvSYYSTPl5AAm4Kfx+AIOD15Do0C4+fviu7KnWpBXen1jpC8Fv0OlWTIa9TfrnRpd
+cDk4fd8bjrr90ahfJ3+rk4uvnHujaFrDqzBI7ENpkpRmxLZGiR9lF6+5mFnLRCoIv3MGSvVLGrgGIY19
+XCxQxEheqoaYT63nbyBV8o7HqHTOUdY3LTsky134idZSw0TU9K0swfA4zIEXeO+7WXSOB6Mjtz4wEhZM
+sBk6Cky50gbMvSzcZg20YjK3ucK4U0Hfkasxzcg2/0/GEvp+DCE+MAoKBZFchNatlZkBBkYxnhCpYmma
+LRcumlvTyM7j4gGgcMa1UUxBg2lIFd5xmelk6WNSGNsZ4XqA98O861Z20kf9l+rYZjOArljmMRDS63HM
+iTgsqWCwYEviSC6iJIvR2dNxTEDCRcE7bmXHg1H+atuFVYY2W9ZojOOCid3s3W3n7DZbcG35bNuRdpCn
+lJbEOGbuIjhxXIR0dpCL480ycbwmDwVH1InEeuin2bFBl6og+DhV6Ex1DUuZgZE5TwEDjTY+lNtaJeGJ
+0GlKlr2kwaCzG02upzBeCshuL99FLEnsoOvjrYnfhHueJDBnd1b1aec2FCtJmdbOweVWVJZwj8r29FJb
+QAJc2Nf5WOuR7EIoD18NvXxrY2uq/Hx6FoJOE25DBPQIt7gELoz0pNZWIGlr2sDrN2/gZmlQt0BL73lB
+IsWMRtGMK+68MwJpFxY9mgfC8dU4zB3aHfD4+fSsBoufT88alThcs/Rbqhs/P+ueDqz50O+Oe2HOh9rb
+lAkzVh/mkk8CWBLXbnzBxDJnQLtvPWfKMoxTE5ejXjH/toxYHdv0x0K+Qa4ti+dqmCAuI6Y+kukMurA6
+TWj3oNBkykb2l2ZuD+3jRjMEFkWYGt3J99sqN1uGjkHefMSICKmcWCi21GUQeexhc5o60wguypTDqXch
+9yq5aii/2mlNh+XdJh1oWLx0GSBeFZQNYuJj7v4wr2GKMr7jGOSeaWCJQhY/Ojq/QjMRejCec50rUOYC
+ZUaSwFkRBhYpqTVovEPFEpuAyXNFziAAGpFaorBFywuwY9pEsrhYn7c1tl2lH9b0IX1iTQuHZbIyzgio
+lFS0cnwwKOJV3KwryJy5eIMoSuyWuJMK9USsYR7fusY1WyrUY3d4jcd90Gic/qMHm6dKktIaFmu8Eku7
+X3xIEx5xkyzdcSjQE2Q87m9LjPG43wzgdAoCuRXEsFhd4gz18bgfAj5wbdwC8/QR7YQbdxSSHNMuKvbq
+LJE3LKlEYODw4CDPhu1CoAK8GhIV7Q1jkk6RPnvC/j4WOrfmgIvYRfkc4rQp7xhTzinvVbo+9gwrbHMX
+X3DUcx4Q148dp0Wm6WAwkdPL9p2dp7CYKyv6Cpu5MnqXCGNlmhqoyw5rAiH0kcyEedvx5vpTzk9v8N6O
+bobFuWPynFvubKC440oKayPdMcUp/gQhjQpb/qAJMxHjlAuMQ+BT4CZ3JjWawAUh3LRutFeQGIMU3gBZ
+LEhVJ1yUx3O4v2+77aO4C3OzhXT1927dzRDMXMn7ir6yMiKW/lijJXqdXKQRndpH5WbyXhvxDMa5QXg6
+hAYRLEwVF2afq3AXQvYG7zcTMIf+kRUEn6CAs0Kqk+5ofNY97fsTjlZ6dtWC0cWJyzKTYZizv5zCCdNm
+wXhiATg1LuJEg+xBb4BCGbQMT+Q7FLFUL/OT0+q2TCSotf24j2EHfpQNH1o7xsbjqcv1eztDZbzeSnrc
+Avb9DppFDCP/8tdTIAduMxny1jxM/5bi9H6bhQi1QKfTMhz/ZFA9PBl2z3pFUGHAFhjNkaWwt2D6FuMi
+RrcHeRbcCRMptnvtrcUZgjtnQxfpCN2BNFWepzcH98gXPfqp173YBa5hbSDGNn2zQMy78/N3/d7kX+fD
+n0cX3aPeY0ZvgeaGeFW5dFBeUONdjSr7v5NyliD8S6pbnbII/xSDdXg3k3i914pYrKevPiMmrRVKVcbF
+t3yx9jjRmGBkpHpbFmVswyyTs6sVfvmTF7bhhcnZ1Zexw+TsaiuOeJKIp+8G58PeSkDCSOAzIRWClgss
+iJgq1DZAwYV1qSwFuxVrvcFFjCmK2IahPQW5skUWTffIjDP2rM18g3lRHiZL/8ldgh1uK5sxdG3rZ32t
+PSzFS+Nteq7zfJD1zKy3GscYe1/gDtUSisyR5ibzKtHAiFYN53eopom832VjZxfnw/FkPOwORifnw5qI
+znqvhlFM6KlUi4khg8+yirwv+WTOZ/PySeD9j0yX6e2iyO76g208vdjUBL9Rc171ls9l/Y6d65uOpLhD
+5d3D04u8IMjVYPqVGAlMABcGZ6iCFcc/RUWbp14LRrEX5jLrMsW8YLSIDJWz70KmixrCXDR4WuE6B0xF
+j54d/u1NRXH2/IfItuACq1r0jEdKajk1cPi3N3+qUAJuM+bUUi36MCiYqFhIi4eJkbconuEgpRiRrvZb
+xIsvNVPJ6H13OagQv0JsBme+5kGjiF0+qsgl/t+mu4OthvSuceWIVDhzRNvLtK3BwmyPqmZvHKLbcMEX
+2U0tMIrZ0vIv5ISzq6KSs7Cb1vLOO8BVY1icXa35EaniUnGzLDf/fJ4FuUWj3vB9b0hBAy60UVlk9FqG
+lwvS2ZVtv9RlWg3u5zaPJGHKbU6W69ISOZEqt1d8d+16WFuDJdrlQQdlTaWHl2YAbjQm0x0wLre3GevK
+9p8KnW6B6KPoqRQV3Fxl6GAEw6EGH8gg7/S6XMjWgbViZPNZgJrUhjBX+3xxGDMcdC/Gw8Lrzx+frbB0
+l01fjGsZ42K8zhNSxahKIUyLex/lO6oNrNRGU00xj1Zz0fiQfoMQweB8cnE5fNdbDxTnUuUBdWkJiJHM
++9jZbKx6gA2LYgHnCjijmokYsjRmBmNXVO2qYRbybifXIF/0huKgvOmJHY+KONLo+UuCakuVKe8ks9kc
++NRWATBb42SNXlJrtHobis9sVVFVvea1f7sAVpNCH3yzavDVsL3X4qiKmqDBCEaOyYs6lgru3n+luLNb
+iQ+fE3D3liS2uN5IyHQZj7bl/5VkBKNRPIZY6DLVYTvxGIWxK3Zx6euimcKEwVaqNB+6n3BtmjsU7q9C
+VkOwlT7rxFumWLFZKIP9tuPz183OyiUrT6KiGmmFQOUJnX5r0jz+1B+bQAVgteQpeuxGnIvxMFdT9POZ
+Tcja4+vx4fVsGsEfM87AydOo/qZekSouSpuIaxROpcJiIE3CQMh9meZZ9aIsblpeCUWBWttLEbsAVHO8
+fOZsGb45nFSuJw1lZvDNYRlQ2ua+ks9fr15bAsJJQPdfIyABMUtoWHFjvvJZSWEgJgbkNxlRtEXde/0f
+W4AmCoKg+b/k4tPjRMzw/HLce3O4A1EL6mzm/aJ5g+R+A3mgz/33+aBXWv15rWvBN3NpL/Q4lyZ26aqS
+xXY2Y/MV1MNBrQ36/ITHlZBX3SWVcNh7v5r1VlSUoxESKW+zNLeHXE6ZwnQCzb1UtwH5ezRDXrFDMzVe
+vgpeB4fBQfv1X19WsunhYfA6eBVwsU9BvoCplAW2zitfQuPl64ODV5345u+dw4PXnU77r3+vDn8dHNCs
+wd+DmyAODoJXwUFwELwOePo3P1lZdWRlweWxXc1YeU3cSH85q9gkGTV+o4TZLiGRYa8mxU3bW7tKueH0
+CEcXJ+UdMns7y9GDUtvjq3GhTaZlRY636fM70ilTRtuiGBd5sfpgmjBjUJDG9qo3vz1GE5+nhi/4L1vW
+eOh0ui/zkbu4opU9b8au0qEaTLQ77VTC4+sZGMXu365Ew9+W/mvLFaFV2j1Gb6szSp81qE7zYM7Yw4j/
+gl+W2QlHw/elL2wf/gCe8GhYw6ej4fvPRqPukc/mpnwmXf8NYlWj0U8nF7Yg1xatMphyMUNlS1GcBIxG
+P7nTjGJSPJrnx5S1Ud1/SDhThDpGCS9uJ6CmXAzXc+fHWStBoEVgF1BpwTWwUtMasCyZEbDzRQU7e2Tl
+T891M3XcfTcCZgyL5qhBCgSpYEG4GDbTa3xIb2jprmRJGNssloWd/z3NaKvJqZe9w5qnK1381U+lNxU0
+5dVM9N1wB6RpR5uBphYqLDeVuMzT4PRHxf1n+/sPIJ20jprt9Ufrt5wzzWYVpsmD4HVMVblB/C2iny4d
+q7mYJVicVmTTu3PZhpkMu0VIFUYYo4hskoMU7Wq4+bosoNy6/LgY2QQ+tdZowncKXNWGSDfFReusLDq/
+8+xC5Szf5m8iaJrf87L9+KrmMuP4arzGhvnFiY2J66/PV1wO+5vqxy6H/Q3FY97VcTWvvpLMF4znnZ2m
+KirJrD6q3iD9/K3R5ygsuxzW8NPlsP/Nisouh/3Dg1eb0Hx8cflJTN19CvxCTF/9fpgeHryqhfXw4NW3
+Q3b15sp6Enn15kVxE9ze2lm5amHTvWsXQ7ZWfqvDm+sx8tBdJ7GJZn9DzPWgjxfLypH7HrjJgz7WUgh/
+/S10aqroa8ODN5WteKOLm9wN24WqFWhraFvpsW662q3a1PPanx2RRfOk1e59ejIlmiHcIF2y06D5gidM
+udt93GigXncsQWGACxAyRvufY/+WmbVFM00wWGSlv/WylJkC97di/sjIL8IsssRwcqLtTRh7GcIVwJo5
+aMOU8ZUy9u8HwiBseYQjlkRZYs3dwiDxRI8ypWht7m+hTsqrNZ2vp4hHZjMxctho0RU/l4nlY2wndDWD
+fHOVCb37LR4pAG0ZWBh81KHdsi9jp486DSSpmdYWWnhDhVGmNL9DKw6hURmGq3/H0XLUAC5oFipV8BNx
+dHayv4lkpPzeyhOEU5ZoDMFI21b+zYuRabmMYGcCWPiepIIDuEqKFhQ7LisiintB/zMAN+I8M85PAAA=
 `,
 	},
 
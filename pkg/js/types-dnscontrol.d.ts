@@ -0,0 +1,483 @@
+// Type declarations for dnsconfig.js, for editor completion and type checking.
+// Generated by build/generate/types.go from docs/_functions and pkg/js/helpers.js. DO NOT HAND EDIT!
+//
+// Reference this file at the top of dnsconfig.js:
+//   /// <reference path="types-dnscontrol.d.ts" />
+
+/** Metadata for a domain or record, such as CF_PROXY_ON. */
+declare interface Metadata {
+    [key: string]: string;
+}
+
+/** A duration in seconds, or a string such as "5m", "1h" or "1d". */
+declare type Duration = number | string;
+
+/** An argument of D(), D_EXTEND() and DEFAULTS(): a record, a domain setting, metadata, or a list of them. */
+declare type DomainModifier = ((domain: any) => void) | Metadata | DomainModifier[];
+
+/** An argument of a record function after its fixed parameters, such as TTL(300). */
+declare type RecordModifier = ((record: any) => void) | Metadata;
+
+/** The values given with --var and --var-file. */
+declare const CLI_VARS: { readonly [key: string]: string };
+
+/**
+ * A adds an A record To a domain. The name should be the relative label for the record. Use `@` for the domain apex.
+ *
+ * @see https://stackexchange.github.io/dnscontrol/js#A
+ */
+declare function A(name: string, address: string | number, ...modifiers: RecordModifier[]): DomainModifier;
+
+/**
+ * AAAA adds an AAAA record To a domain. The name should be the relative label for the record. Use `@` for the domain apex.
+ *
+ * @see https://stackexchange.github.io/dnscontrol/js#AAAA
+ */
+declare function AAAA(name: string, address: string, ...modifiers: RecordModifier[]): DomainModifier;
+
+/**
+ * ALIAS is a virtual record type that points a record at another record. It is analogous to a CNAME, but is usually resolved at request-time and served as an A record. Unlike CNAMEs, ALIAS records can be used at the zone apex (`@`)
+ *
+ * @see https://stackexchange.github.io/dnscontrol/js#ALIAS
+ */
+declare function ALIAS(name: string, target: string, ...modifiers: RecordModifier[]): DomainModifier;
+
+/**
+ * CAA adds a CAA record to a domain. The name should be the relative label for the record. Use `@` for the domain apex.
+ *
+ * @see https://stackexchange.github.io/dnscontrol/js#CAA
+ */
+declare function CAA(name: string, tag: "issue" | "issuewild" | "iodef", value: string, ...modifiers: RecordModifier[]): DomainModifier;
+
+/**
+ * `CAA_BUILDER` creates the CAA records that allow only the listed certificate
+ * authorities to issue certificates. See [CAA Builder](https://stackexchange.github.io/dnscontrol/caa-builder).
+ *
+ * @see https://stackexchange.github.io/dnscontrol/js#CAA_BUILDER
+ */
+declare function CAA_BUILDER(options: { label?: string, iodef?: string, iodef_critical?: boolean, issue?: string[] | "none", issuewild?: string[] | "none" }): DomainModifier;
+
+/**
+ * CAA_CRITICAL: Critical CAA flag
+ */
+declare const CAA_CRITICAL: RecordModifier;
+
+/**
+ * Proxy default off for entire domain (the default):
+ */
+declare const CF_PROXY_DEFAULT_OFF: Metadata;
+
+/**
+ * Proxy default on for entire domain:
+ */
+declare const CF_PROXY_DEFAULT_ON: Metadata;
+
+/**
+ * Proxy+Railgun enabled.
+ */
+declare const CF_PROXY_FULL: Metadata;
+
+/**
+ * Proxy disabled.
+ */
+declare const CF_PROXY_OFF: Metadata;
+
+/**
+ * Proxy enabled.
+ */
+declare const CF_PROXY_ON: Metadata;
+
+/**
+ * `CF_REDIRECT` is the same as `CF_TEMP_REDIRECT` but generates a
+ * http 301 redirect (permanent redirect) instead of a temporary
+ * redirect.
+ *
+ * Only supported by CLOUDFLAREAPI.
+ *
+ * @see https://stackexchange.github.io/dnscontrol/js#CF_REDIRECT
+ */
+declare function CF_REDIRECT(source: string, destination: string, ...modifiers: RecordModifier[]): DomainModifier;
+
+/**
+ * `CF_TEMP_REDIRECT` uses Cloudflare-specific features ("page rules") to
+ * generate an HTTP 302 (temporary) redirect.
+ *
+ * Only supported by CLOUDFLAREAPI.
+ *
+ * @see https://stackexchange.github.io/dnscontrol/js#CF_TEMP_REDIRECT
+ */
+declare function CF_TEMP_REDIRECT(source: string, destination: string, ...modifiers: RecordModifier[]): DomainModifier;
+
+/**
+ * UniversalSSL off for entire domain:
+ */
+declare const CF_UNIVERSALSSL_OFF: Metadata;
+
+/**
+ * UniversalSSL on for entire domain:
+ */
+declare const CF_UNIVERSALSSL_ON: Metadata;
+
+/**
+ * CNAME adds a CNAME record to the domain. The name should be the relative label for the domain.
+ * Using `@` or `*` for CNAME records is not recommended, as different providers support them differently.
+ *
+ * @see https://stackexchange.github.io/dnscontrol/js#CNAME
+ */
+declare function CNAME(name: string, target: string, ...modifiers: RecordModifier[]): DomainModifier;
+
+/**
+ * `D` adds a new Domain for DNSControl to manage. The first two arguments are required: the domain name (fully qualified `example.com` without a trailing dot), and the
+ * name of the registrar (as previously declared with [NewRegistrar](https://stackexchange.github.io/dnscontrol/js#NewRegistrar)). Any number of additional arguments may be included to add DNS Providers with [DNSProvider](https://stackexchange.github.io/dnscontrol/js#DNSProvider),
+ * add records with [A](https://stackexchange.github.io/dnscontrol/js#A), [CNAME](https://stackexchange.github.io/dnscontrol/js#CNAME), and so forth, or add metadata.
+ *
+ * @see https://stackexchange.github.io/dnscontrol/js#D
+ */
+declare function D(name: string, registrar: string, ...modifiers: DomainModifier[]): void;
+
+/**
+ * `DEFAULTS` allows you to declare a set of default arguments to apply to all subsequent domains. Subsequent calls to [D](https://stackexchange.github.io/dnscontrol/js#D) will have these
+ * arguments passed as if they were the first modifiers in the argument list.
+ *
+ * @see https://stackexchange.github.io/dnscontrol/js#DEFAULTS
+ */
+declare function DEFAULTS(...modifiers: DomainModifier[]): void;
+
+/**
+ * `DKIM` splits a DKIM key into strings of at most 255 bytes, so that a long key
+ * can be used as the contents of a `TXT` record.
+ *
+ * @see https://stackexchange.github.io/dnscontrol/js#DKIM
+ */
+declare function DKIM(key: string): string[];
+
+/**
+ * `DOMAIN_TEMPLATE` declares a template of records and modifiers that many domains can share
+ * with [USE_TEMPLATE](https://stackexchange.github.io/dnscontrol/js#USE_TEMPLATE). The function is called with the parameters given to
+ * `USE_TEMPLATE` and returns anything `D()` accepts: records, modifiers, metadata objects or
+ * arrays of them. Templates may use other templates.
+ *
+ * @see https://stackexchange.github.io/dnscontrol/js#DOMAIN_TEMPLATE
+ */
+declare function DOMAIN_TEMPLATE(name: string, function_: (params: any) => DomainModifier): void;
+
+/**
+ * `D_EXTEND` adds records and modifiers to a domain that was already declared with [D](https://stackexchange.github.io/dnscontrol/js#D).
+ * This allows a zone to be split across several files, for example one per team, that are
+ * loaded with [require](https://stackexchange.github.io/dnscontrol/js#require) after the main `D()`. It is an error to extend a domain that
+ * has not been declared yet.
+ *
+ * @see https://stackexchange.github.io/dnscontrol/js#D_EXTEND
+ */
+declare function D_EXTEND(name: string, ...modifiers: DomainModifier[]): void;
+
+/**
+ * DefaultTTL sets the TTL for all records in a domain that do not explicitly set one with [TTL](https://stackexchange.github.io/dnscontrol/js#TTL). If neither `DefaultTTl` or `TTL` exist for a record,
+ * it will use the DNSControl global default of 300 seconds.
+ *
+ * @see https://stackexchange.github.io/dnscontrol/js#DefaultTTL
+ */
+declare function DefaultTTL(ttl: Duration): DomainModifier;
+
+/**
+ * DnsProvider indicates that the specified provider should be used to manage
+ * records for this domain. The name must match the name used with [NewDnsProvider](https://stackexchange.github.io/dnscontrol/js#NewDnsProvider).
+ *
+ * @see https://stackexchange.github.io/dnscontrol/js#DnsProvider
+ */
+declare function DnsProvider(name: string, nsCount?: number): DomainModifier;
+
+/**
+ * `ENV(name)` returns the value of the environment variable `name`, or
+ * `undefined` if it is not set. Only the variables allowed on the command line
+ * with `--allow-env` can be read; `ENV()` throws an error for any other name.
+ * The values that were read are recorded in the IR (see `print-ir`).
+ *
+ * @see https://stackexchange.github.io/dnscontrol/js#ENV
+ */
+declare function ENV(name: string): string | undefined;
+
+/**
+ * `FASTMAIL` adds the MX, SPF and DKIM records of Fastmail.
+ * It uses the latest version of the vendor's record set unless
+ * `options.version` is given. See
+ * [Vendor record sets](https://stackexchange.github.io/dnscontrol/vendor-records) for the options.
+ *
+ * @see https://stackexchange.github.io/dnscontrol/js#FASTMAIL
+ */
+declare function FASTMAIL(options?: { version?: number, spf?: boolean }): DomainModifier;
+
+/**
+ * `FRAME` adds a Namecheap "masked redirect" record: `name` shows the page at
+ * `target` in a frame.
+ *
+ * Only supported by NAMECHEAP.
+ *
+ * @see https://stackexchange.github.io/dnscontrol/js#FRAME
+ */
+declare function FRAME(name: string, target: string, ...modifiers: RecordModifier[]): DomainModifier;
+
+/**
+ * `GOOGLE_WORKSPACE` adds the MX, SPF, site verification and DKIM
+ * records of Google Workspace.
+ * It uses the latest version of the vendor's record set unless
+ * `options.version` is given. See
+ * [Vendor record sets](https://stackexchange.github.io/dnscontrol/vendor-records) for the options.
+ *
+ * @see https://stackexchange.github.io/dnscontrol/js#GOOGLE_WORKSPACE
+ */
+declare function GOOGLE_WORKSPACE(options?: { label?: string, version?: number, spf?: boolean, verification?: string, dkim?: string, dkim_selector?: string }): DomainModifier;
+
+/**
+ * `GOOGLE_WORKSPACE_MX` adds the MX records of Google Workspace.
+ * It uses the latest version of the vendor's record set unless
+ * `options.version` is given. See
+ * [Vendor record sets](https://stackexchange.github.io/dnscontrol/vendor-records) for the options.
+ *
+ * @see https://stackexchange.github.io/dnscontrol/js#GOOGLE_WORKSPACE_MX
+ */
+declare function GOOGLE_WORKSPACE_MX(options?: { label?: string, version?: number }): DomainModifier;
+
+/**
+ * IGNORE can be used to ignore some records presents in zone.
+ * All records (independently of their type) of that name will be completely ignored.
+ *
+ * @see https://stackexchange.github.io/dnscontrol/js#IGNORE
+ */
+declare function IGNORE(name: string): DomainModifier;
+
+/**
+ * Don't use this feature. It was added for a very specific situation at Stack Overflow.
+ *
+ * @see https://stackexchange.github.io/dnscontrol/js#IMPORT_TRANSFORM
+ */
+declare function IMPORT_TRANSFORM(transform_table: { low: string, high: string, newBase?: string | string[], newIP?: string | string[] }[], domain: string, ttl: number, ...modifiers: RecordModifier[]): DomainModifier;
+
+/**
+ * Converts the IP address from string to an integer. This allows performing mathematical operations with the IP address.
+ *
+ * @see https://stackexchange.github.io/dnscontrol/js#IP
+ */
+declare function IP(ip: string): number;
+
+/**
+ * `M365` adds the Exchange Online records of Microsoft 365.
+ * It uses the latest version of the vendor's record set unless
+ * `options.version` is given. See
+ * [Vendor record sets](https://stackexchange.github.io/dnscontrol/vendor-records) for the options.
+ *
+ * @see https://stackexchange.github.io/dnscontrol/js#M365
+ */
+declare function M365(options: { tenant: string, mx_token?: string, version?: number, spf?: boolean, verification?: string, teams?: boolean, mdm?: boolean }): DomainModifier;
+
+/**
+ * `MAILGUN` adds the records of a Mailgun sending domain.
+ * It uses the latest version of the vendor's record set unless
+ * `options.version` is given. See
+ * [Vendor record sets](https://stackexchange.github.io/dnscontrol/vendor-records) for the options.
+ *
+ * @see https://stackexchange.github.io/dnscontrol/js#MAILGUN
+ */
+declare function MAILGUN(options?: { region?: "us" | "eu", subdomain?: string, version?: number, spf?: boolean, dkim?: string, dkim_selector?: string, tracking?: boolean }): DomainModifier;
+
+/**
+ * MX adds an MX record to the domain.
+ *
+ * @see https://stackexchange.github.io/dnscontrol/js#MX
+ */
+declare function MX(name: string, priority: number, target: string, ...modifiers: RecordModifier[]): DomainModifier;
+
+/**
+ * `NAMESERVER()` instructs DNSControl to inform the domain's registrar where to find this zone.
+ * For some registrars this will also add NS records to the zone itself.
+ *
+ * @see https://stackexchange.github.io/dnscontrol/js#NAMESERVER
+ */
+declare function NAMESERVER(name: string, ...modifiers: RecordModifier[]): DomainModifier;
+
+/**
+ * TTL sets the TTL on the domain apex NS RRs defined by [NAMESERVER](https://stackexchange.github.io/dnscontrol/js#NAMESERVER).
+ *
+ * @see https://stackexchange.github.io/dnscontrol/js#NAMESERVER_TTL
+ */
+declare function NAMESERVER_TTL(ttl: Duration): DomainModifier;
+
+/**
+ * `NAPTR` adds a `NAPTR` record to a domain. The name should be the relative label for the record.
+ *
+ * @see https://stackexchange.github.io/dnscontrol/js#NAPTR
+ */
+declare function NAPTR(name: string, order: number, preference: number, flags: string, service: string, regexp: string, target: string, ...modifiers: RecordModifier[]): DomainModifier;
+
+/**
+ * NO_PURGE indicates that records should not be deleted from a domain.
+ * Records will be added and updated, but not removed.
+ *
+ * @see https://stackexchange.github.io/dnscontrol/js#NO_PURGE
+ */
+declare const NO_PURGE: DomainModifier;
+
+/**
+ * NS adds a NS record to the domain. The name should be the relative label for the domain.
+ * Use `@` for the domain apex, though if you are doing this consider using NAMESERVER() instead.
+ *
+ * @see https://stackexchange.github.io/dnscontrol/js#NS
+ */
+declare function NS(name: string, target: string, ...modifiers: RecordModifier[]): DomainModifier;
+
+/**
+ * NewDnsProvider registers a new DNS Service Provider. The name can be any string value you would like to use.
+ * The type must match a valid dns provider type identifier (see [provider page.](https://stackexchange.github.io/dnscontrol/provider-list))
+ *
+ * @see https://stackexchange.github.io/dnscontrol/js#NewDnsProvider
+ */
+declare function NewDnsProvider(name: string, type?: string, meta?: object): string;
+
+/**
+ * NewRegistrar registers a registrar provider. The name can be any string value you would like to use.
+ * The type must match a valid registrar provider type identifier (see [provider page.](https://stackexchange.github.io/dnscontrol/provider-list))
+ *
+ * @see https://stackexchange.github.io/dnscontrol/js#NewRegistrar
+ */
+declare function NewRegistrar(name: string, type?: string, meta?: object): string;
+
+/**
+ * PTR adds a PTR record to the domain.
+ *
+ * @see https://stackexchange.github.io/dnscontrol/js#PTR
+ */
+declare function PTR(name: string, target: string, ...modifiers: RecordModifier[]): DomainModifier;
+
+/**
+ * PURGE is the default setting for all domains.  Therefore PURGE is
+ * a no-op. It is included for completeness only.
+ *
+ * @see https://stackexchange.github.io/dnscontrol/js#PURGE
+ */
+declare const PURGE: DomainModifier;
+
+/**
+ * R53_ALIAS is a Route53 specific virtual record type that points a record at either another record or an AWS entity (like a Cloudfront distribution, an ELB, etc...). It is analogous to a CNAME, but is usually resolved at request-time and served as an A record. Unlike CNAMEs, ALIAS records can be used at the zone apex (`@`)
+ *
+ * Only supported by ROUTE53.
+ *
+ * @see https://stackexchange.github.io/dnscontrol/js#R53_ALIAS
+ */
+declare function R53_ALIAS(name: string, type: string, target: string, ...modifiers: RecordModifier[]): DomainModifier;
+
+/**
+ * R53_ZONE sets the required Route53 hosted zone id in a R53_ALIAS record.
+ *
+ * @see https://stackexchange.github.io/dnscontrol/js#R53_ZONE
+ */
+declare function R53_ZONE(zone_id: string): RecordModifier;
+
+/**
+ * `REV` returns the reverse lookup domain for an IP network. For
+ * example `REV('1.2.3.0/24')` returns `3.2.1.in-addr.arpa.` and
+ * `REV('2001:db8:302::/48)` returns `2.0.3.0.8.b.d.0.1.0.0.2.ip6.arpa.`.
+ * This is used in `D()` functions to create reverse DNS lookup zones.
+ *
+ * @see https://stackexchange.github.io/dnscontrol/js#REV
+ */
+declare function REV(address: string): string;
+
+/**
+ * `SPF_BUILDER` builds the SPF TXT record of a domain from a list of parts,
+ * optionally flattening includes. See [SPF Optimizer](https://stackexchange.github.io/dnscontrol/spf-optimizer).
+ *
+ * @see https://stackexchange.github.io/dnscontrol/js#SPF_BUILDER
+ */
+declare function SPF_BUILDER(options: { parts: string[], label?: string, raw?: string, ttl?: Duration, split?: string, flatten?: string[], overflow?: string, txtMaxSize?: number }): DomainModifier;
+
+/**
+ * `SRV` adds a `SRV` record to a domain. The name should be the relative label for the record.
+ *
+ * @see https://stackexchange.github.io/dnscontrol/js#SRV
+ */
+declare function SRV(name: string, priority: number, weight: number, port: number, target: string, ...modifiers: RecordModifier[]): DomainModifier;
+
+/**
+ * SSHFP contains a fingerprint of a SSH server which can be validated before SSH clients are establishing the connection.
+ *
+ * @see https://stackexchange.github.io/dnscontrol/js#SSHFP
+ */
+declare function SSHFP(name: string, algorithm: number, type: number, value: string, ...modifiers: RecordModifier[]): DomainModifier;
+
+/**
+ * TAGS attaches one or more tags to a domain. Tags are not sent to any provider;
+ * they are only used to select domains on the command line with `-tags`.
+ *
+ * @see https://stackexchange.github.io/dnscontrol/js#TAGS
+ */
+declare function TAGS(...tags: string[]): DomainModifier;
+
+/**
+ * TLSA adds a TLSA record to a domain. The name should be the relative label for the record.
+ *
+ * @see https://stackexchange.github.io/dnscontrol/js#TLSA
+ */
+declare function TLSA(name: string, usage: number, selector: number, type: number, certificate: string, ...modifiers: RecordModifier[]): DomainModifier;
+
+/**
+ * TTL sets the TTL for a single record only. This will take precedence
+ * over the domain's [DefaultTTL](https://stackexchange.github.io/dnscontrol/js#DefaultTTL) if supplied.
+ *
+ * @see https://stackexchange.github.io/dnscontrol/js#TTL
+ */
+declare function TTL(ttl: Duration): RecordModifier;
+
+/**
+ * TXT adds an TXT record To a domain. The name should be the relative
+ * label for the record. Use `@` for the domain apex.
+ *
+ * @see https://stackexchange.github.io/dnscontrol/js#TXT
+ */
+declare function TXT(name: string, contents: string | string[], ...modifiers: RecordModifier[]): DomainModifier;
+
+/**
+ * `URL` adds a Namecheap "URL redirect" record: requests for `name` are
+ * redirected to `target` with an HTTP 302 redirect.
+ *
+ * Only supported by NAMECHEAP.
+ *
+ * @see https://stackexchange.github.io/dnscontrol/js#URL
+ */
+declare function URL(name: string, target: string, ...modifiers: RecordModifier[]): DomainModifier;
+
+/**
+ * `URL301` adds a Namecheap "permanent redirect" record: requests for `name`
+ * are redirected to `target` with an HTTP 301 redirect.
+ *
+ * Only supported by NAMECHEAP.
+ *
+ * @see https://stackexchange.github.io/dnscontrol/js#URL301
+ */
+declare function URL301(name: string, target: string, ...modifiers: RecordModifier[]): DomainModifier;
+
+/**
+ * `USE_TEMPLATE` adds the records and modifiers of a template declared with
+ * [DOMAIN_TEMPLATE](https://stackexchange.github.io/dnscontrol/js#DOMAIN_TEMPLATE) to the domain. `params` is passed to the
+ * template function; it defaults to `{}`. The template must be declared before it is used.
+ *
+ * @see https://stackexchange.github.io/dnscontrol/js#USE_TEMPLATE
+ */
+declare function USE_TEMPLATE(name: string, params?: { [key: string]: any }): DomainModifier;
+
+/**
+ * `require(...)` behaves similarly to its equivalent in node.js. You can use it
+ * to split your configuration across multiple files. If the path starts with a
+ * `.`, it is calculated relative to the current file. For example:
+ *
+ * @see https://stackexchange.github.io/dnscontrol/js#require
+ */
+declare function require(path: string): any;
+
+/**
+ * `require_glob()` runs [require](https://stackexchange.github.io/dnscontrol/js#require) on every `.js` file in the
+ * directory `path`. If `recursive` is `true` (the default), files in
+ * subdirectories are loaded too; pass `false` to load only the top directory.
+ *
+ * @see https://stackexchange.github.io/dnscontrol/js#require_glob
+ */
+declare function require_glob(path: string, recursive?: boolean): void;
@@ -0,0 +1,129 @@
+package lock
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// FileLocker stores one lock file per zone in a directory. It only protects against
+// concurrent pushes on the same host (or hosts sharing the directory on a filesystem
+// with atomic exclusive create).
+type FileLocker struct {
+	Dir        string
+	StaleAfter time.Duration
+	// BreakStale removes stale locks instead of failing.
+	BreakStale bool
+}
+
+// NewFileLocker creates a FileLocker storing locks in dir.
+func NewFileLocker(dir string, staleAfter time.Duration, breakStale bool) (*FileLocker, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, errors.Errorf("creating lock directory %s: %s", dir, err)
+	}
+	return &FileLocker{Dir: dir, StaleAfter: staleAfter, BreakStale: breakStale}, nil
+}
+
+func (f *FileLocker) path(zone string) string {
+	return filepath.Join(f.Dir, strings.Replace(zone, string(filepath.Separator), "_", -1)+".lock")
+}
+
+// maxLockAttempts bounds how often Lock tries again after a lock file was removed under it.
+const maxLockAttempts = 5
+
+// Lock creates the lock file for zone, failing if it already exists.
+func (f *FileLocker) Lock(zone string) (Lock, error) {
+	info := newInfo(zone)
+	dat, err := json.MarshalIndent(info, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	path := f.path(zone)
+	broken := false
+	for attempt := 0; attempt < maxLockAttempts; attempt++ {
+		fh, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if err == nil {
+			_, err = fh.Write(dat)
+			if cerr := fh.Close(); err == nil {
+				err = cerr
+			}
+			if err != nil {
+				os.Remove(path)
+				return nil, err
+			}
+			return &fileLock{path: path, zone: zone, token: info.Token}, nil
+		}
+		if !os.IsExist(err) {
+			return nil, err
+		}
+		holder, err := readFileLock(path, zone)
+		if err != nil {
+			return nil, err
+		}
+		if holder == nil {
+			// Released between our create and read: try again.
+			continue
+		}
+		stale := holder.isStale(f.StaleAfter)
+		if !stale || !f.BreakStale || broken {
+			return nil, &LockedError{Holder: holder, Stale: stale}
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		broken = true
+	}
+	return nil, errors.Errorf("could not lock %s: the lock file %s keeps changing", zone, path)
+}
+
+// readFileLock reads the lock file at path. It returns nil if there is no lock file.
+func readFileLock(path, zone string) (*Info, error) {
+	dat, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	info := &Info{}
+	if err := json.Unmarshal(dat, info); err != nil {
+		// An unreadable lock file was most likely left by a crash while writing it.
+		st, serr := os.Stat(path)
+		if os.IsNotExist(serr) {
+			return nil, nil
+		}
+		if serr != nil {
+			return nil, serr
+		}
+		return &Info{Zone: zone, Created: st.ModTime()}, nil
+	}
+	info.Zone = zone
+	return info, nil
+}
+
+type fileLock struct {
+	path  string
+	zone  string
+	token string
+}
+
+// Unlock removes the lock file if it is still ours. A stale lock may have been taken over
+// by another push, whose lock must stay.
+func (l *fileLock) Unlock() error {
+	holder, err := readFileLock(l.path, l.zone)
+	if err != nil {
+		return err
+	}
+	if holder == nil {
+		return errors.Errorf("lock for %s was removed by someone else", l.zone)
+	}
+	if holder.Token != l.token {
+		return errors.Errorf("lock for %s was taken over by %s", l.zone, holder)
+	}
+	return os.Remove(l.path)
+}
@@ -0,0 +1,101 @@
+package lock
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFileLocker(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dnscontrol-lock")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	locker, err := NewFileLocker(dir, time.Hour, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lk, err := locker.Lock("example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = locker.Lock("example.com")
+	if le, ok := err.(*LockedError); !ok || le.Stale {
+		t.Fatalf("expected a LockedError that is not stale, got %v", err)
+	}
+	if _, err := locker.Lock("example.net"); err != nil {
+		t.Fatalf("other zones should not be locked: %s", err)
+	}
+	if err := lk.Unlock(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := locker.Lock("example.com"); err != nil {
+		t.Fatalf("expected lock to be released: %s", err)
+	}
+}
+
+func TestFileLockerStale(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dnscontrol-lock")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	locker, err := NewFileLocker(dir, time.Hour, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	old := newInfo("example.com")
+	old.Created = time.Now().Add(-2 * time.Hour)
+	dat, _ := json.Marshal(old)
+	if err := ioutil.WriteFile(locker.path("example.com"), dat, 0644); err != nil {
+		t.Fatal(err)
+	}
+	_, err = locker.Lock("example.com")
+	if le, ok := err.(*LockedError); !ok || !le.Stale {
+		t.Fatalf("expected a stale LockedError, got %v", err)
+	}
+	locker.BreakStale = true
+	if _, err := locker.Lock("example.com"); err != nil {
+		t.Fatalf("expected stale lock to be broken: %s", err)
+	}
+}
+
+func TestFileLockerTakenOver(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dnscontrol-lock")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	locker, err := NewFileLocker(dir, time.Hour, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	old, err := locker.Lock("example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Another push takes over the lock, as it does with a stale lock and -break-stale-locks.
+	if err := os.Remove(locker.path("example.com")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := locker.Lock("example.com"); err != nil {
+		t.Fatal(err)
+	}
+	if err := old.Unlock(); err == nil || !strings.Contains(err.Error(), "taken over") {
+		t.Errorf("expected an error unlocking a lock that was taken over, got %v", err)
+	}
+	if _, err := os.Stat(locker.path("example.com")); err != nil {
+		t.Errorf("the new holder's lock was removed: %s", err)
+	}
+}
+
+func TestReadFileLockMissing(t *testing.T) {
+	holder, err := readFileLock("/nonexistent/example.com.lock", "example.com")
+	if holder != nil || err != nil {
+		t.Errorf("expected no lock for a missing file, got %v, %v", holder, err)
+	}
+}
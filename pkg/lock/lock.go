@@ -0,0 +1,94 @@
+// Package lock provides per-zone locks so that concurrent pushes do not interleave corrections on the same zone.
+//
+// Two backends are provided: a directory of lock files for a single host, and hashicorp vault for
+// setups where pushes run on several hosts. Other backends can implement the Locker interface.
+package lock
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"os/user"
+	"time"
+)
+
+// DefaultStaleAfter is how old a lock must be before it is considered stale.
+const DefaultStaleAfter = time.Hour
+
+// Locker acquires exclusive locks on zones.
+type Locker interface {
+	// Lock acquires the lock for zone. It returns *LockedError if the zone is already locked.
+	Lock(zone string) (Lock, error)
+}
+
+// Lock is a held lock.
+type Lock interface {
+	Unlock() error
+}
+
+// Info describes the holder of a lock. It is stored in the lock so that conflicts can be reported.
+type Info struct {
+	Zone    string    `json:"zone"`
+	User    string    `json:"user"`
+	Host    string    `json:"host"`
+	PID     int       `json:"pid"`
+	Created time.Time `json:"created"`
+	Token   string    `json:"token"`
+}
+
+func (i *Info) String() string {
+	return fmt.Sprintf("%s@%s (pid %d) since %s", i.User, i.Host, i.PID, i.Created.Format(time.RFC3339))
+}
+
+// newInfo describes a lock held by this process.
+func newInfo(zone string) *Info {
+	info := &Info{
+		Zone:    zone,
+		PID:     os.Getpid(),
+		Created: time.Now().UTC(),
+	}
+	info.Host, _ = os.Hostname()
+	if u, err := user.Current(); err == nil {
+		info.User = u.Username
+	}
+	b := make([]byte, 16)
+	rand.Read(b)
+	info.Token = hex.EncodeToString(b)
+	return info
+}
+
+// isStale reports whether the lock holder is gone or has held the lock for longer than staleAfter.
+func (i *Info) isStale(staleAfter time.Duration) bool {
+	if staleAfter > 0 && time.Since(i.Created) > staleAfter {
+		return true
+	}
+	host, _ := os.Hostname()
+	return i.Host == host && i.PID != 0 && !processExists(i.PID)
+}
+
+// LockedError is returned when a zone is locked by someone else.
+type LockedError struct {
+	Holder *Info
+	// Stale is true if the holder appears to be gone. The lock is not broken automatically.
+	Stale bool
+}
+
+func (e *LockedError) Error() string {
+	if e.Stale {
+		return fmt.Sprintf("%s has a stale lock held by %s; remove it or use -break-stale-locks", e.Holder.Zone, e.Holder)
+	}
+	return fmt.Sprintf("%s is locked by %s", e.Holder.Zone, e.Holder)
+}
+
+// None is a Locker that does no locking.
+type None struct{}
+
+// Lock always succeeds.
+func (None) Lock(zone string) (Lock, error) {
+	return noLock{}, nil
+}
+
+type noLock struct{}
+
+func (noLock) Unlock() error { return nil }
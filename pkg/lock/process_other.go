@@ -0,0 +1,11 @@
+// +build !windows
+
+package lock
+
+import "syscall"
+
+// processExists reports whether a process with the given pid is running on this host.
+func processExists(pid int) bool {
+	err := syscall.Kill(pid, 0)
+	return err == nil || err == syscall.EPERM
+}
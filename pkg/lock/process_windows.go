@@ -0,0 +1,7 @@
+package lock
+
+// processExists reports whether a process with the given pid is running on this host.
+// It is not implemented on windows, so locks there only go stale by age.
+func processExists(pid int) bool {
+	return true
+}
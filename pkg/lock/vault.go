@@ -0,0 +1,112 @@
+package lock
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/vault/api"
+	"github.com/pkg/errors"
+)
+
+// VaultLocker stores locks as secrets in hashicorp vault, so that pushes on several hosts
+// can coordinate. The client is configured from the standard VAULT_ADDR and VAULT_TOKEN
+// environment variables, as for get-certs -vault.
+//
+// Vault's generic secret backend has no compare-and-swap, so the lock is written and then
+// read back to verify that no other writer won. This is adequate for serializing CI runs,
+// not for high contention.
+type VaultLocker struct {
+	Path       string
+	StaleAfter time.Duration
+	BreakStale bool
+	client     *api.Logical
+}
+
+// NewVaultLocker creates a VaultLocker storing locks under vaultPath.
+func NewVaultLocker(vaultPath string, staleAfter time.Duration, breakStale bool) (*VaultLocker, error) {
+	if !strings.HasSuffix(vaultPath, "/") {
+		vaultPath += "/"
+	}
+	client, err := api.NewClient(api.DefaultConfig())
+	if err != nil {
+		return nil, err
+	}
+	return &VaultLocker{
+		Path:       vaultPath,
+		StaleAfter: staleAfter,
+		BreakStale: breakStale,
+		client:     client.Logical(),
+	}, nil
+}
+
+func (v *VaultLocker) read(path, zone string) (*Info, error) {
+	secret, err := v.client.Read(path)
+	if err != nil || secret == nil {
+		return nil, err
+	}
+	s, ok := secret.Data["lock"].(string)
+	if !ok {
+		return nil, errors.Errorf("Secret at %s does not have a lock", path)
+	}
+	info := &Info{}
+	if err := json.Unmarshal([]byte(s), info); err != nil {
+		return nil, errors.Errorf("Secret at %s: %s", path, err)
+	}
+	info.Zone = zone
+	return info, nil
+}
+
+// Lock writes the lock secret for zone, failing if it already exists.
+func (v *VaultLocker) Lock(zone string) (Lock, error) {
+	path := v.Path + zone
+	holder, err := v.read(path, zone)
+	if err != nil {
+		return nil, err
+	}
+	if holder != nil {
+		stale := holder.isStale(v.StaleAfter)
+		if !stale || !v.BreakStale {
+			return nil, &LockedError{Holder: holder, Stale: stale}
+		}
+	}
+	info := newInfo(zone)
+	dat, err := json.Marshal(info)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := v.client.Write(path, map[string]interface{}{"lock": string(dat)}); err != nil {
+		return nil, err
+	}
+	holder, err = v.read(path, zone)
+	if err != nil {
+		return nil, err
+	}
+	if holder == nil || holder.Token != info.Token {
+		if holder == nil {
+			holder = &Info{Zone: zone}
+		}
+		return nil, &LockedError{Holder: holder}
+	}
+	return &vaultLock{locker: v, path: path, zone: zone, token: info.Token}, nil
+}
+
+type vaultLock struct {
+	locker *VaultLocker
+	path   string
+	zone   string
+	token  string
+}
+
+// Unlock deletes the lock secret if it is still ours.
+func (l *vaultLock) Unlock() error {
+	holder, err := l.locker.read(l.path, l.zone)
+	if err != nil {
+		return err
+	}
+	if holder == nil || holder.Token != l.token {
+		return errors.Errorf("lock for %s was taken over by %v", l.zone, holder)
+	}
+	_, err = l.locker.client.Delete(l.path)
+	return err
+}
@@ -28,7 +28,7 @@ func flattenSPFs(cfg *models.DNSConfig) []error {
 				}
 				rec, err = spflib.Parse(txt.GetTargetField(), cache)
 				if err != nil {
-					errs = append(errs, err)
+					errs = append(errs, withSource(txt, err))
 					continue
 				}
 			}
@@ -36,14 +36,14 @@ func flattenSPFs(cfg *models.DNSConfig) []error {
 				rec = rec.Flatten(flatten)
 				err = txt.SetTargetTXT(rec.TXT())
 				if err != nil {
-					errs = append(errs, err)
+					errs = append(errs, withSource(txt, err))
 					continue
 				}
 			}
 			// now split if needed
 			if split, ok := txt.Metadata["split"]; ok {
 				if !strings.Contains(split, "%d") {
-					errs = append(errs, Warning{errors.Errorf("Split format `%s` in `%s` is not proper format (should have %%d in it)", split, txt.GetLabelFQDN())})
+					errs = append(errs, withSource(txt, Warning{errors.Errorf("Split format `%s` in `%s` is not proper format (should have %%d in it)", split, txt.GetLabelFQDN())}))
 					continue
 				}
 				recs := rec.TXTSplit(split + "." + domain.Name)
@@ -0,0 +1,63 @@
+package normalize
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/StackExchange/dnscontrol/models"
+	"github.com/pkg/errors"
+)
+
+// templateErrors reports an error in a record made by USE_TEMPLATE once,
+// instead of once for every domain that uses the template.
+type templateErrors struct {
+	first   map[string]int          // error (without the domain name) -> index of its first report
+	domains map[int]map[string]bool // index of a report -> domains with that error
+	names   map[int]string          // index of a report -> template name
+}
+
+func newTemplateErrors() *templateErrors {
+	return &templateErrors{
+		first:   map[string]int{},
+		domains: map[int]map[string]bool{},
+		names:   map[int]string{},
+	}
+}
+
+// dedupe drops the errors in errs[n:] about rec that were already reported
+// for the same template in another domain.
+func (t *templateErrors) dedupe(errs []error, n int, rec *models.RecordConfig, domain *models.DomainConfig) []error {
+	tmpl := rec.Metadata["template"]
+	if tmpl == "" {
+		return errs
+	}
+	kept := errs[:n]
+	for _, err := range errs[n:] {
+		key := tmpl + "\x00" + strings.Replace(err.Error(), domain.Name, "", -1)
+		if i, ok := t.first[key]; ok {
+			t.domains[i][domain.UniqueName()] = true
+			continue
+		}
+		i := len(kept)
+		t.first[key] = i
+		t.domains[i] = map[string]bool{domain.UniqueName(): true}
+		t.names[i] = tmpl
+		kept = append(kept, err)
+	}
+	return kept
+}
+
+// finish notes on each reported error how many domains it was found in.
+func (t *templateErrors) finish(errs []error) {
+	for i, domains := range t.domains {
+		if len(domains) < 2 {
+			continue
+		}
+		suffix := fmt.Sprintf(" (template %s, same error in %d domains)", t.names[i], len(domains))
+		if w, ok := errs[i].(Warning); ok {
+			errs[i] = Warning{errors.Errorf("%s%s", w.error, suffix)}
+		} else {
+			errs[i] = errors.Errorf("%s%s", errs[i], suffix)
+		}
+	}
+}
@@ -261,8 +261,22 @@ type Warning struct {
 	error
 }
 
+// withSource prefixes err with the location where rec was defined, if known.
+// Warnings stay Warnings.
+func withSource(rec *models.RecordConfig, err error) error {
+	loc := rec.SourceLocation()
+	if loc == "" || err == nil {
+		return err
+	}
+	if w, ok := err.(Warning); ok {
+		return Warning{errors.Errorf("%s: %s", loc, w.error)}
+	}
+	return errors.Errorf("%s: %s", loc, err)
+}
+
 // NormalizeAndValidateConfig performs and normalization and/or validation of the IR.
 func NormalizeAndValidateConfig(config *models.DNSConfig) (errs []error) {
+	tmplErrs := newTemplateErrors()
 	for _, domain := range config.Domains {
 		pTypes := []string{}
 		txtMultiDissenters := []string{}
@@ -287,6 +301,7 @@ func NormalizeAndValidateConfig(config *models.DNSConfig) (errs []error) {
 		// Normalize Records.
 		models.PostProcessRecords(domain.Records)
 		for _, rec := range domain.Records {
+			n := len(errs)
 			if rec.TTL == 0 {
 				rec.TTL = models.DefaultTTL
 			}
@@ -344,8 +359,14 @@ func NormalizeAndValidateConfig(config *models.DNSConfig) (errs []error) {
 
 			// Populate FQDN:
 			rec.SetLabel(rec.GetLabel(), domain.Name)
+
+			for i := n; i < len(errs); i++ {
+				errs[i] = withSource(rec, errs[i])
+			}
+			errs = tmplErrs.dedupe(errs, n, rec, domain)
 		}
 	}
+	tmplErrs.finish(errs)
 
 	// SPF flattening
 	if ers := flattenSPFs(config); len(ers) > 0 {
@@ -358,12 +379,12 @@ func NormalizeAndValidateConfig(config *models.DNSConfig) (errs []error) {
 			if rec.Type == "IMPORT_TRANSFORM" {
 				table, err := transform.DecodeTransformTable(rec.Metadata["transform_table"])
 				if err != nil {
-					errs = append(errs, err)
+					errs = append(errs, withSource(rec, err))
 					continue
 				}
 				err = importTransform(config.FindDomain(rec.GetTargetField()), domain, table, rec.TTL)
 				if err != nil {
-					errs = append(errs, err)
+					errs = append(errs, withSource(rec, err))
 				}
 			}
 		}
@@ -379,6 +400,9 @@ func NormalizeAndValidateConfig(config *models.DNSConfig) (errs []error) {
 		}
 	}
 
+	// Check that views of a zone don't fight over the same provider or the delegation
+	errs = append(errs, checkViews(config.Domains)...)
+
 	for _, d := range config.Domains {
 		// Check that CNAMES don't have to co-exist with any other records
 		errs = append(errs, checkCNAMEs(d)...)
@@ -389,6 +413,8 @@ func NormalizeAndValidateConfig(config *models.DNSConfig) (errs []error) {
 		}
 		// Check for duplicates
 		errs = append(errs, checkDuplicates(d.Records)...)
+		// Check for outdated vendor record sets
+		errs = append(errs, checkVendorVersions(d)...)
 		// Validate FQDN consistency
 		for _, r := range d.Records {
 			if r.NameFQDN == "" || !strings.HasSuffix(r.NameFQDN, d.Name) {
@@ -400,19 +426,58 @@ func NormalizeAndValidateConfig(config *models.DNSConfig) (errs []error) {
 	return errs
 }
 
+// checkViews checks the domains that are views of the same zone. A DNS provider manages one
+// record set per zone, so each push of one view would undo the other, unless the provider
+// keeps views apart (CanUseViews). Likewise only one view may have a registrar other than NONE,
+// since the registrar sets the zone's delegation.
+func checkViews(domains []*models.DomainConfig) (errs []error) {
+	zones := map[string][]*models.DomainConfig{}
+	names := []string{}
+	for _, d := range domains {
+		if zones[d.Name] == nil {
+			names = append(names, d.Name)
+		}
+		zones[d.Name] = append(zones[d.Name], d)
+	}
+	for _, name := range names {
+		views := zones[name]
+		if len(views) < 2 {
+			continue
+		}
+		usedBy := map[string]string{}
+		registered := ""
+		for _, d := range views {
+			for _, p := range d.DNSProviderInstances {
+				if other, ok := usedBy[p.Name]; ok && !providers.ProviderHasCabability(p.ProviderType, providers.CanUseViews) {
+					errs = append(errs, errors.Errorf("%s and %s both use DNS provider %s, which can't keep views of a zone apart: each push would undo the other. Use a separate provider for each view", other, d.UniqueName(), p.Name))
+				}
+				usedBy[p.Name] = d.UniqueName()
+			}
+			if d.RegistrarInstance == nil || d.RegistrarInstance.ProviderType == "NONE" {
+				continue
+			}
+			if registered != "" {
+				errs = append(errs, errors.Errorf("%s and %s both have a registrar: only one view of a zone may set its delegation. Use a NONE registrar for the other views", registered, d.UniqueName()))
+			}
+			registered = d.UniqueName()
+		}
+	}
+	return errs
+}
+
 func checkCNAMEs(dc *models.DomainConfig) (errs []error) {
 	cnames := map[string]bool{}
 	for _, r := range dc.Records {
 		if r.Type == "CNAME" {
 			if cnames[r.GetLabel()] {
-				errs = append(errs, errors.Errorf("Cannot have multiple CNAMEs with same name: %s", r.GetLabelFQDN()))
+				errs = append(errs, withSource(r, errors.Errorf("Cannot have multiple CNAMEs with same name: %s", r.GetLabelFQDN())))
 			}
 			cnames[r.GetLabel()] = true
 		}
 	}
 	for _, r := range dc.Records {
 		if cnames[r.GetLabel()] && r.Type != "CNAME" {
-			errs = append(errs, errors.Errorf("Cannot have CNAME and %s record with same name: %s", r.Type, r.GetLabelFQDN()))
+			errs = append(errs, withSource(r, errors.Errorf("Cannot have CNAME and %s record with same name: %s", r.Type, r.GetLabelFQDN())))
 		}
 	}
 	return
@@ -423,7 +488,7 @@ func checkDuplicates(records []*models.RecordConfig) (errs []error) {
 	for _, r := range records {
 		diffable := fmt.Sprintf("%s %s %s", r.GetLabelFQDN(), r.Type, r.ToDiffable())
 		if seen[diffable] != nil {
-			errs = append(errs, errors.Errorf("Exact duplicate record found: %s", diffable))
+			errs = append(errs, withSource(r, errors.Errorf("Exact duplicate record found: %s", diffable)))
 		}
 		seen[diffable] = r
 	}
@@ -472,12 +537,12 @@ func applyRecordTransforms(domain *models.DomainConfig) error {
 		}
 		table, err := transform.DecodeTransformTable(tt)
 		if err != nil {
-			return err
+			return withSource(rec, err)
 		}
 		ip := net.ParseIP(rec.GetTargetField()) // ip already validated above
 		newIPs, err := transform.TransformIPToList(net.ParseIP(rec.GetTargetField()), table)
 		if err != nil {
-			return err
+			return withSource(rec, err)
 		}
 		for i, newIP := range newIPs {
 			if i == 0 && !newIP.Equal(ip) {
@@ -4,8 +4,10 @@ import (
 	"testing"
 
 	"fmt"
+	"strings"
 
 	"github.com/StackExchange/dnscontrol/models"
+	"github.com/StackExchange/dnscontrol/providers"
 )
 
 func TestCheckLabel(t *testing.T) {
@@ -279,3 +281,128 @@ func TestTLSAValidation(t *testing.T) {
 		t.Error("Expect error on invalid TLSA but got none")
 	}
 }
+
+func TestSourceLocation(t *testing.T) {
+	config := &models.DNSConfig{
+		Domains: []*models.DomainConfig{
+			{
+				Name:          "example.com",
+				RegistrarName: "BIND",
+				Records: []*models.RecordConfig{
+					makeRC("foo", "example.com", "bar.example.net", models.RecordConfig{
+						Type: "CNAME", Metadata: map[string]string{"source_location": "records/cnames.js:12"}}),
+					makeRC("_baz", "example.com", "1.2.3.4", models.RecordConfig{
+						Type: "A", Metadata: map[string]string{"source_location": "dnsconfig.js:3"}}),
+				},
+			},
+		},
+	}
+	errs := NormalizeAndValidateConfig(config)
+	if len(errs) != 2 {
+		t.Fatalf("Expect 2 errors but got %q", errs)
+	}
+	if !strings.HasPrefix(errs[0].Error(), "records/cnames.js:12: ") {
+		t.Errorf("Expect error to start with the source location: %s", errs[0])
+	}
+	if _, ok := errs[1].(Warning); !ok || !strings.HasPrefix(errs[1].Error(), "dnsconfig.js:3: ") {
+		t.Errorf("Expect warning to start with the source location: %s", errs[1])
+	}
+}
+
+func TestTemplateErrorsReportedOnce(t *testing.T) {
+	config := &models.DNSConfig{}
+	for _, name := range []string{"brand1.com", "brand2.com", "brand3.com"} {
+		config.Domains = append(config.Domains, &models.DomainConfig{
+			Name:          name,
+			RegistrarName: "BIND",
+			Records: []*models.RecordConfig{
+				makeRC("www", name, "parking.example.net", models.RecordConfig{
+					Type: "CNAME", Metadata: map[string]string{"template": "parked", "source_location": "templates.js:4"}}),
+			},
+		})
+	}
+	errs := NormalizeAndValidateConfig(config)
+	if len(errs) != 1 {
+		t.Fatalf("Expect 1 error but got %q", errs)
+	}
+	if !strings.HasSuffix(errs[0].Error(), "(template parked, same error in 3 domains)") {
+		t.Errorf("Expect error to count the domains: %s", errs[0])
+	}
+}
+
+func TestVendorVersions(t *testing.T) {
+	vendor := func(version string) map[string]string {
+		return map[string]string{"vendor": "google_workspace", "vendor_version": version, "vendor_latest": "2"}
+	}
+	config := &models.DNSConfig{
+		Domains: []*models.DomainConfig{
+			{
+				Name:          "current.com",
+				RegistrarName: "BIND",
+				Records: []*models.RecordConfig{
+					makeRC("@", "current.com", "smtp.google.com.", models.RecordConfig{Type: "MX", Metadata: vendor("2")}),
+				},
+			},
+			{
+				Name:          "outdated.com",
+				RegistrarName: "BIND",
+				Records: []*models.RecordConfig{
+					makeRC("@", "outdated.com", "aspmx.l.google.com.", models.RecordConfig{Type: "MX", MxPreference: 1, Metadata: vendor("1")}),
+					makeRC("@", "outdated.com", "alt1.aspmx.l.google.com.", models.RecordConfig{Type: "MX", MxPreference: 5, Metadata: vendor("1")}),
+				},
+			},
+		},
+	}
+	errs := NormalizeAndValidateConfig(config)
+	if len(errs) != 1 {
+		t.Fatalf("Expect 1 warning but got %q", errs)
+	}
+	if _, ok := errs[0].(Warning); !ok {
+		t.Errorf("Expect a warning but got %v", errs[0])
+	}
+	if !strings.Contains(errs[0].Error(), "outdated.com uses version 1 of the google_workspace records") {
+		t.Errorf("Unexpected warning: %s", errs[0])
+	}
+}
+
+func TestCheckViews(t *testing.T) {
+	if _, ok := providers.DNSProviderTypes["VIEWS_TEST"]; !ok {
+		providers.RegisterDomainServiceProviderType("VIEWS_TEST", nil, providers.CanUseViews)
+	}
+	view := func(name, view, reg string, dsps ...string) *models.DomainConfig {
+		d := &models.DomainConfig{Name: name, View: view, RegistrarInstance: &models.RegistrarInstance{ProviderBase: models.ProviderBase{Name: reg, ProviderType: reg}}}
+		for _, p := range dsps {
+			typ := "CLOUDFLAREAPI"
+			if p == "views" {
+				typ = "VIEWS_TEST"
+			}
+			d.DNSProviderInstances = append(d.DNSProviderInstances, &models.DNSProviderInstance{ProviderBase: models.ProviderBase{Name: p, ProviderType: typ}})
+		}
+		return d
+	}
+	tests := []struct {
+		desc    string
+		domains []*models.DomainConfig
+		wantErr string
+	}{
+		{"separate providers", []*models.DomainConfig{view("example.com", "", "NAMEDOTCOM", "cf"), view("example.com", "internal", "NONE", "views")}, ""},
+		{"shared provider with views", []*models.DomainConfig{view("example.com", "a", "NONE", "views"), view("example.com", "b", "NONE", "views")}, ""},
+		{"other zones", []*models.DomainConfig{view("example.com", "", "NAMEDOTCOM", "cf"), view("example.net", "", "NAMEDOTCOM", "cf")}, ""},
+		{"shared provider", []*models.DomainConfig{view("example.com", "", "NONE", "cf"), view("example.com", "internal", "NONE", "cf")}, "both use DNS provider cf"},
+		{"two registrars", []*models.DomainConfig{view("example.com", "", "NAMEDOTCOM", "cf"), view("example.com", "internal", "NAMEDOTCOM", "views")}, "both have a registrar"},
+	}
+	for _, tst := range tests {
+		t.Run(tst.desc, func(t *testing.T) {
+			errs := checkViews(tst.domains)
+			if tst.wantErr == "" {
+				if len(errs) != 0 {
+					t.Errorf("expected no errors, got %v", errs)
+				}
+				return
+			}
+			if len(errs) != 1 || !strings.Contains(errs[0].Error(), tst.wantErr) {
+				t.Errorf("expected an error %q, got %v", tst.wantErr, errs)
+			}
+		})
+	}
+}
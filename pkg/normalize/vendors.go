@@ -0,0 +1,24 @@
+package normalize
+
+import (
+	"github.com/StackExchange/dnscontrol/models"
+	"github.com/pkg/errors"
+)
+
+// checkVendorVersions warns about records made by a vendor helper
+// (GOOGLE_WORKSPACE, M365, ...) with an outdated version of the vendor's
+// record set. There is one warning per vendor and domain.
+func checkVendorVersions(dc *models.DomainConfig) (errs []error) {
+	warned := map[string]bool{}
+	for _, r := range dc.Records {
+		vendor, version, latest := r.Metadata["vendor"], r.Metadata["vendor_version"], r.Metadata["vendor_latest"]
+		if vendor == "" || version == latest || warned[vendor] {
+			continue
+		}
+		warned[vendor] = true
+		errs = append(errs, withSource(r, Warning{errors.Errorf(
+			"%s uses version %s of the %s records, the latest is version %s. Review the changes and remove the version option",
+			dc.UniqueName(), version, vendor, latest)}))
+	}
+	return errs
+}
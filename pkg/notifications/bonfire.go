@@ -7,11 +7,11 @@ import (
 )
 
 func init() {
-	initers = append(initers, func(cfg map[string]string) Notifier {
+	initers = append(initers, func(cfg map[string]string) (Notifier, error) {
 		if url, ok := cfg["bonfire_url"]; ok {
-			return bonfireNotifier(url)
+			return bonfireNotifier(url), nil
 		}
-		return nil
+		return nil, nil
 	})
 }
 
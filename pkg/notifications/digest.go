@@ -0,0 +1,77 @@
+package notifications
+
+// digest collects notifications for notifiers that send one summary per run.
+// Results are grouped by domain, then provider, in the order they were first seen.
+type digest struct {
+	preview   bool
+	domains   []string
+	providers map[string][]string
+	results   map[string][]digestResult
+}
+
+type digestResult struct {
+	msg string
+	err error
+}
+
+// digestGroup is the results for one domain and provider.
+type digestGroup struct {
+	domain, provider string
+	results          []digestResult
+}
+
+func (d *digest) add(domain, provider, msg string, err error, preview bool) {
+	if d.results == nil {
+		d.providers = map[string][]string{}
+		d.results = map[string][]digestResult{}
+	}
+	d.preview = preview
+	if _, ok := d.providers[domain]; !ok {
+		d.domains = append(d.domains, domain)
+	}
+	key := domain + "\x00" + provider
+	if _, ok := d.results[key]; !ok {
+		d.providers[domain] = append(d.providers[domain], provider)
+	}
+	d.results[key] = append(d.results[key], digestResult{msg: msg, err: err})
+}
+
+func (d *digest) empty() bool {
+	return len(d.domains) == 0
+}
+
+func (d *digest) reset() {
+	*d = digest{}
+}
+
+// split returns the failed and successful results, each grouped by domain and provider.
+func (d *digest) split() (errs, oks []digestGroup) {
+	for _, domain := range d.domains {
+		for _, provider := range d.providers[domain] {
+			e := digestGroup{domain: domain, provider: provider}
+			o := digestGroup{domain: domain, provider: provider}
+			for _, r := range d.results[domain+"\x00"+provider] {
+				if r.err != nil {
+					e.results = append(e.results, r)
+				} else {
+					o.results = append(o.results, r)
+				}
+			}
+			if len(e.results) > 0 {
+				errs = append(errs, e)
+			}
+			if len(o.results) > 0 {
+				oks = append(oks, o)
+			}
+		}
+	}
+	return errs, oks
+}
+
+func countResults(groups []digestGroup) int {
+	n := 0
+	for _, g := range groups {
+		n += len(g.results)
+	}
+	return n
+}
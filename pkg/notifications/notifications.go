@@ -11,20 +11,25 @@ type Notifier interface {
 	Done()
 }
 
-// new notification types should add themselves to this array
-var initers = []func(map[string]string) Notifier{}
+// new notification types should add themselves to this array. An initer returns nil if the config
+// does not mention its notifier, and an error if the config of its notifier is wrong.
+var initers = []func(map[string]string) (Notifier, error){}
 
 // Init will take the given config map (from creds.json notifications key) and create a single Notifier with
-// all notifications it has full config for.
-func Init(config map[string]string) Notifier {
+// all notifications it has full config for. It returns an error if a notifier is configured incorrectly,
+// rather than run without it.
+func Init(config map[string]string) (Notifier, error) {
 	notifiers := multiNotifier{}
 	for _, i := range initers {
-		n := i(config)
+		n, err := i(config)
+		if err != nil {
+			return nil, err
+		}
 		if n != nil {
 			notifiers = append(notifiers, n)
 		}
 	}
-	return notifiers
+	return notifiers, nil
 }
 
 type multiNotifier []Notifier
@@ -0,0 +1,148 @@
+package notifications
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/gobwas/glob"
+	"github.com/pkg/errors"
+)
+
+// Event names accepted in a route's _events key.
+const (
+	EventErrors    = "errors"
+	EventSuccesses = "successes"
+	EventPush      = "push"
+	EventPreview   = "preview"
+)
+
+// route restricts which notifications reach a notifier.
+// Empty fields match everything.
+type route struct {
+	domains   []glob.Glob
+	providers map[string]bool
+	outcomes  map[string]bool // EventErrors, EventSuccesses
+	modes     map[string]bool // EventPush, EventPreview
+}
+
+// parseRoute reads the routing keys of a notifications section:
+//
+//	_domains    comma-separated domain globs, for example "*.example.com,example.com"
+//	_providers  comma-separated provider names
+//	_events     comma-separated events: errors, successes, push, preview
+func parseRoute(cfg map[string]string) (*route, error) {
+	r := &route{providers: map[string]bool{}, outcomes: map[string]bool{}, modes: map[string]bool{}}
+	for _, d := range splitList(cfg["_domains"]) {
+		g, err := glob.Compile(strings.ToLower(d), '.')
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid domain pattern %q", d)
+		}
+		r.domains = append(r.domains, g)
+	}
+	for _, p := range splitList(cfg["_providers"]) {
+		r.providers[p] = true
+	}
+	for _, e := range splitList(cfg["_events"]) {
+		switch e {
+		case EventErrors, EventSuccesses:
+			r.outcomes[e] = true
+		case EventPush, EventPreview:
+			r.modes[e] = true
+		default:
+			return nil, errors.Errorf("unknown event %q (expected errors, successes, push or preview)", e)
+		}
+	}
+	return r, nil
+}
+
+// match reports whether a notification matches the route. domain may be a view,
+// "example.com!internal", which matches the patterns of its zone and of the view.
+func (r *route) match(domain, provider string, err error, preview bool) bool {
+	if len(r.domains) > 0 {
+		names := []string{strings.ToLower(domain)}
+		if i := strings.Index(domain, "!"); i >= 0 {
+			names = append(names, strings.ToLower(domain[:i]))
+		}
+		found := false
+		for _, g := range r.domains {
+			for _, name := range names {
+				if g.Match(name) {
+					found = true
+				}
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(r.providers) > 0 && !r.providers[provider] {
+		return false
+	}
+	outcome, mode := EventSuccesses, EventPush
+	if err != nil {
+		outcome = EventErrors
+	}
+	if preview {
+		mode = EventPreview
+	}
+	if len(r.outcomes) > 0 && !r.outcomes[outcome] {
+		return false
+	}
+	if len(r.modes) > 0 && !r.modes[mode] {
+		return false
+	}
+	return true
+}
+
+func splitList(s string) []string {
+	var out []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+// routedNotifier passes on only the notifications that match its route.
+type routedNotifier struct {
+	Notifier
+	route *route
+}
+
+func (r *routedNotifier) Notify(domain, provider string, message string, err error, preview bool) {
+	if r.route.match(domain, provider, err, preview) {
+		r.Notifier.Notify(domain, provider, message, err, preview)
+	}
+}
+
+// InitRoutes creates a Notifier from every section of the credentials file named "notifications"
+// or starting with "notifications." (for example "notifications.payments"). Each section configures
+// its own notifiers, and may restrict what they are sent with the _domains, _providers and _events keys.
+func InitRoutes(configs map[string]map[string]string) (Notifier, error) {
+	names := []string{}
+	for name := range configs {
+		if name == "notifications" || strings.HasPrefix(name, "notifications.") {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	notifiers := multiNotifier{}
+	for _, name := range names {
+		cfg := configs[name]
+		r, err := parseRoute(cfg)
+		if err != nil {
+			return nil, errors.Wrap(err, name)
+		}
+		notifier, err := Init(cfg)
+		if err != nil {
+			return nil, errors.Wrap(err, name)
+		}
+		n := notifier.(multiNotifier)
+		if len(n) == 0 && name != "notifications" {
+			return nil, errors.Errorf("%s does not configure any notifier", name)
+		}
+		notifiers = append(notifiers, &routedNotifier{Notifier: n, route: r})
+	}
+	return notifiers, nil
+}
@@ -0,0 +1,98 @@
+package notifications
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRouteMatch(t *testing.T) {
+	r, err := parseRoute(map[string]string{
+		"_domains":   "*.pay.example.com, pay.example.com",
+		"_providers": "r53",
+		"_events":    "errors,push",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fail := errors.New("boom")
+	tests := []struct {
+		domain, provider string
+		err              error
+		preview          bool
+		want             bool
+	}{
+		{"pay.example.com", "r53", fail, false, true},
+		{"eu.pay.example.com", "r53", fail, false, true},
+		{"a.eu.pay.example.com", "r53", fail, false, false},
+		{"example.com", "r53", fail, false, false},
+		{"pay.example.com", "bind", fail, false, false},
+		{"pay.example.com", "r53", nil, false, false},
+		{"pay.example.com", "r53", fail, true, false},
+	}
+	for _, tst := range tests {
+		if got := r.match(tst.domain, tst.provider, tst.err, tst.preview); got != tst.want {
+			t.Errorf("match(%s, %s, %v, %v) = %v, want %v", tst.domain, tst.provider, tst.err, tst.preview, got, tst.want)
+		}
+	}
+}
+
+func TestRouteMatchViews(t *testing.T) {
+	for _, tst := range []struct {
+		domains, domain string
+		want            bool
+	}{
+		{"example.com", "example.com!internal", true},
+		{"*.example.com", "pay.example.com!internal", true},
+		{"example.com!internal", "example.com!internal", true},
+		{"example.com!internal", "example.com", false},
+		{"example.com!internal", "example.com!external", false},
+		{"example.net", "example.com!internal", false},
+	} {
+		r, err := parseRoute(map[string]string{"_domains": tst.domains})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := r.match(tst.domain, "bind", nil, false); got != tst.want {
+			t.Errorf("_domains %s: match(%s) = %v, want %v", tst.domains, tst.domain, got, tst.want)
+		}
+	}
+}
+
+func TestParseRouteErrors(t *testing.T) {
+	if _, err := parseRoute(map[string]string{"_events": "sometimes"}); err == nil {
+		t.Error("expected error for unknown event")
+	}
+	if _, err := parseRoute(map[string]string{"_domains": "[a"}); err == nil {
+		t.Error("expected error for bad glob")
+	}
+}
+
+func TestInitRoutes(t *testing.T) {
+	all := newRecordingServer()
+	defer all.Close()
+	payments := newRecordingServer()
+	defer payments.Close()
+	n, err := InitRoutes(map[string]map[string]string{
+		"r53":                    {"KeyId": "x"},
+		"notifications":          {"webhook_url": all.URL},
+		"notifications.payments": {"webhook_url": payments.URL, "_domains": "pay.example.com"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	n.Notify("pay.example.com", "r53", "one", nil, false)
+	n.Notify("www.example.com", "r53", "two", nil, false)
+	n.Done()
+	if len(all.bodies) != 2 {
+		t.Errorf("expected 2 unrouted messages, got %d", len(all.bodies))
+	}
+	if len(payments.bodies) != 1 || !strings.Contains(payments.bodies[0], "pay.example.com") {
+		t.Errorf("expected only the payments message, got %v", payments.bodies)
+	}
+
+	_, err = InitRoutes(map[string]map[string]string{"notifications.empty": {"_events": "errors"}})
+	if err == nil {
+		t.Error("expected error for a section without notifiers")
+	}
+}
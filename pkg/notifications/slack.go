@@ -0,0 +1,160 @@
+package notifications
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/StackExchange/dnscontrol/pkg/printer"
+)
+
+func init() {
+	initers = append(initers, func(cfg map[string]string) (Notifier, error) {
+		if url, ok := cfg["slack_url"]; ok {
+			return &slackNotifier{url: url, client: &http.Client{Timeout: 30 * time.Second}, retryDelay: time.Second}, nil
+		}
+		return nil, nil
+	})
+}
+
+// Slack's limits: the longest text, in characters, of a section block, and the most blocks in a message.
+const (
+	slackMaxSection = 3000
+	slackMaxBlocks  = 50
+)
+
+// slackEscape escapes the characters that are control characters in Slack's mrkdwn.
+var slackEscape = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace
+
+// slackNotifier posts one digest message per run to a Slack incoming webhook.
+type slackNotifier struct {
+	url        string
+	client     *http.Client
+	retryDelay time.Duration
+
+	digest
+}
+
+func (s *slackNotifier) Notify(domain, provider, msg string, err error, preview bool) {
+	s.add(domain, provider, msg, err, preview)
+}
+
+func (s *slackNotifier) Done() {
+	if s.empty() {
+		return
+	}
+	payload := s.payload()
+	body, err := json.Marshal(payload)
+	if err != nil {
+		printer.Warnf("slack notification: %s\n", err)
+		return
+	}
+	req := &httpRequest{method: http.MethodPost, url: s.url, contentType: "application/json", body: body}
+	if err := req.send(s.client, 3, s.retryDelay); err != nil {
+		printer.Warnf("slack notification failed: %s\n", err)
+	}
+	s.reset()
+}
+
+type slackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type slackBlock struct {
+	Type string     `json:"type"`
+	Text *slackText `json:"text,omitempty"`
+}
+
+type slackPayload struct {
+	Text   string       `json:"text"`
+	Blocks []slackBlock `json:"blocks"`
+}
+
+// payload builds the digest: a title, then a section for errors and one for successful (or planned) changes.
+func (s *slackNotifier) payload() *slackPayload {
+	errGroups, okGroups := s.split()
+	nErrs, nOks := countResults(errGroups), countResults(okGroups)
+	var errs, oks []string
+	for _, g := range errGroups {
+		lines := []string{fmt.Sprintf("*%s* [%s]", slackEscape(g.domain), slackEscape(g.provider))}
+		for _, r := range g.results {
+			lines = append(lines, fmt.Sprintf("• %s\n    _%s_", slackEscape(r.msg), slackEscape(r.err.Error())))
+		}
+		errs = append(errs, strings.Join(lines, "\n"))
+	}
+	for _, g := range okGroups {
+		lines := []string{fmt.Sprintf("*%s* [%s]", slackEscape(g.domain), slackEscape(g.provider))}
+		for _, r := range g.results {
+			lines = append(lines, "• "+slackEscape(r.msg))
+		}
+		oks = append(oks, strings.Join(lines, "\n"))
+	}
+
+	title := fmt.Sprintf("DNSControl push: %d changes made", nOks)
+	if nErrs > 0 {
+		title += fmt.Sprintf(", %d failed", nErrs)
+	}
+	okHeading := ":white_check_mark: *Changes made*"
+	if s.preview {
+		title = fmt.Sprintf("DNSControl PREVIEW: %d changes pending. Nothing was changed.", nOks)
+		okHeading = ":mag: *Pending changes*"
+	}
+	p := &slackPayload{Text: title}
+	p.Blocks = append(p.Blocks, slackBlock{Type: "section", Text: &slackText{Type: "mrkdwn", Text: "*" + title + "*"}})
+	if len(errs) > 0 {
+		// Errors come first, but leave room for a divider and two sections of changes.
+		budget := slackMaxBlocks - len(p.Blocks) - 1
+		if len(oks) > 0 {
+			budget -= 3
+		}
+		p.Blocks = append(p.Blocks, slackBlock{Type: "divider"})
+		p.Blocks = append(p.Blocks, slackSections(":x: *Errors*", errs, budget)...)
+	}
+	if len(oks) > 0 {
+		budget := slackMaxBlocks - len(p.Blocks) - 1
+		p.Blocks = append(p.Blocks, slackBlock{Type: "divider"})
+		p.Blocks = append(p.Blocks, slackSections(okHeading, oks, budget)...)
+	}
+	return p
+}
+
+// slackSections puts the heading and groups into as few sections as Slack's size limit allows, and
+// at most maxBlocks (at least 2) sections. Groups that don't fit are counted in a last section.
+func slackSections(heading string, groups []string, maxBlocks int) []slackBlock {
+	section := func(text string) slackBlock {
+		return slackBlock{Type: "section", Text: &slackText{Type: "mrkdwn", Text: text}}
+	}
+	var blocks []slackBlock
+	text := heading
+	for i, g := range groups {
+		g = slackTruncate(g, slackMaxSection)
+		if utf8.RuneCountInString(text)+1+utf8.RuneCountInString(g) <= slackMaxSection {
+			text += "\n" + g
+			continue
+		}
+		// Starting another section must leave room for the one that counts the rest.
+		if len(blocks)+3 > maxBlocks {
+			return append(blocks, section(text), section(fmt.Sprintf("_…and %d more_", len(groups)-i)))
+		}
+		blocks = append(blocks, section(text))
+		text = g
+	}
+	return append(blocks, section(text))
+}
+
+// slackTruncate shortens escaped mrkdwn text to at most max characters, ending it with an ellipsis.
+// It cuts on a character boundary and never inside an escape such as &amp;.
+func slackTruncate(text string, max int) string {
+	if utf8.RuneCountInString(text) <= max {
+		return text
+	}
+	runes := []rune(text)[:max-1]
+	if amp := strings.LastIndex(string(runes), "&"); amp >= 0 && !strings.Contains(string(runes)[amp:], ";") {
+		return string(runes)[:amp] + "…"
+	}
+	return string(runes) + "…"
+}
@@ -0,0 +1,157 @@
+package notifications
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestSlackDigest(t *testing.T) {
+	srv := newRecordingServer()
+	defer srv.Close()
+	n := mustInit(t, map[string]string{"slack_url": srv.URL})
+	n.Notify("example.com", "bind", "CREATE A www", nil, false)
+	n.Notify("example.net", "r53", "DELETE A old", fmt.Errorf("denied"), false)
+	n.Notify("example.com", "bind", "CREATE A api", nil, false)
+	if len(srv.bodies) != 0 {
+		t.Fatalf("expected no messages before Done, got %d", len(srv.bodies))
+	}
+	n.Done()
+	if len(srv.bodies) != 1 {
+		t.Fatalf("expected 1 message, got %d", len(srv.bodies))
+	}
+	p := &slackPayload{}
+	if err := json.Unmarshal([]byte(srv.bodies[0]), p); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(p.Text, "2 changes made, 1 failed") {
+		t.Errorf("unexpected title %q", p.Text)
+	}
+	var errSection, okSection string
+	for _, b := range p.Blocks {
+		if b.Text == nil {
+			continue
+		}
+		if strings.HasPrefix(b.Text.Text, ":x:") {
+			errSection = b.Text.Text
+		}
+		if strings.HasPrefix(b.Text.Text, ":white_check_mark:") {
+			okSection = b.Text.Text
+		}
+	}
+	if !strings.Contains(errSection, "*example.net* [r53]") || !strings.Contains(errSection, "denied") {
+		t.Errorf("unexpected error section %q", errSection)
+	}
+	if strings.Count(okSection, "*example.com* [bind]") != 1 || !strings.Contains(okSection, "CREATE A api") {
+		t.Errorf("expected successes grouped by domain and provider, got %q", okSection)
+	}
+}
+
+func TestSlackPreview(t *testing.T) {
+	srv := newRecordingServer()
+	defer srv.Close()
+	n := mustInit(t, map[string]string{"slack_url": srv.URL})
+	n.Done()
+	if len(srv.bodies) != 0 {
+		t.Fatalf("expected no message for an empty run, got %d", len(srv.bodies))
+	}
+	n.Notify("example.com", "bind", "CREATE A www", nil, true)
+	n.Done()
+	if len(srv.bodies) != 1 || !strings.Contains(srv.bodies[0], "PREVIEW") {
+		t.Fatalf("expected a preview message, got %v", srv.bodies)
+	}
+}
+
+func TestSlackSectionsSplit(t *testing.T) {
+	groups := []string{strings.Repeat("a", 2000), strings.Repeat("b", 2000)}
+	blocks := slackSections("heading", groups, slackMaxBlocks)
+	if len(blocks) != 2 {
+		t.Fatalf("expected 2 sections, got %d", len(blocks))
+	}
+	for _, b := range blocks {
+		if len(b.Text.Text) > slackMaxSection {
+			t.Errorf("section is %d long", len(b.Text.Text))
+		}
+	}
+}
+
+func TestSlackEscape(t *testing.T) {
+	srv := newRecordingServer()
+	defer srv.Close()
+	n := mustInit(t, map[string]string{"slack_url": srv.URL})
+	n.Notify("example.com", "<!channel>", "CREATE TXT @ \"a&b <https://evil|click>\"", fmt.Errorf("<@U123> & more"), false)
+	n.Done()
+	p := &slackPayload{}
+	if err := json.Unmarshal([]byte(srv.bodies[0]), p); err != nil {
+		t.Fatal(err)
+	}
+	var text string
+	for _, b := range p.Blocks {
+		if b.Text != nil {
+			text += b.Text.Text + "\n"
+		}
+	}
+	if strings.ContainsAny(text, "<>") {
+		t.Errorf("unescaped text %q", text)
+	}
+	for _, want := range []string{"[&lt;!channel&gt;]", "a&amp;b &lt;https://evil|click&gt;", "&lt;@U123&gt; &amp; more"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("expected %q in %q", want, text)
+		}
+	}
+}
+
+func TestSlackSectionsTruncate(t *testing.T) {
+	for _, group := range []string{
+		strings.Repeat("é", 5000),
+		strings.Repeat("a", slackMaxSection-3) + "&amp;&amp;",
+	} {
+		blocks := slackSections("heading", []string{group}, slackMaxBlocks)
+		for _, b := range blocks {
+			text := b.Text.Text
+			if !utf8.ValidString(text) {
+				t.Errorf("section is not valid UTF-8")
+			}
+			if n := utf8.RuneCountInString(text); n > slackMaxSection {
+				t.Errorf("section is %d characters long", n)
+			}
+			if strings.HasSuffix(strings.TrimSuffix(text, "…"), "&") || strings.Contains(text, "&a…") {
+				t.Errorf("section was cut inside an escape: %q", text[len(text)-20:])
+			}
+		}
+		if last := blocks[len(blocks)-1].Text.Text; !strings.HasSuffix(last, "…") {
+			t.Errorf("expected the long group to be truncated")
+		}
+	}
+}
+
+func TestSlackMaxBlocks(t *testing.T) {
+	s := &slackNotifier{}
+	long := strings.Repeat("x", 2000)
+	for i := 0; i < 60; i++ {
+		s.Notify(fmt.Sprintf("ok%d.com", i), "bind", long, nil, false)
+		s.Notify(fmt.Sprintf("failed%d.com", i), "bind", long, fmt.Errorf("denied"), false)
+	}
+	p := s.payload()
+	if len(p.Blocks) > slackMaxBlocks {
+		t.Fatalf("expected at most %d blocks, got %d", slackMaxBlocks, len(p.Blocks))
+	}
+	var more []string
+	changes := false
+	for _, b := range p.Blocks {
+		if b.Text == nil {
+			continue
+		}
+		if strings.HasPrefix(b.Text.Text, "_…and ") {
+			more = append(more, b.Text.Text)
+		}
+		if strings.HasPrefix(b.Text.Text, ":white_check_mark:") {
+			changes = true
+		}
+	}
+	if len(more) != 2 || !changes {
+		t.Errorf("expected the errors and the changes to be cut short and still listed, got %v, changes %v", more, changes)
+	}
+}
@@ -0,0 +1,270 @@
+package notifications
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"fmt"
+	"html/template"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"os"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/pkg/printer"
+	"github.com/pkg/errors"
+)
+
+func init() {
+	initers = append(initers, func(cfg map[string]string) (Notifier, error) {
+		host, ok := cfg["smtp_host"]
+		if !ok {
+			return nil, nil
+		}
+		n := &smtpNotifier{
+			host:     host,
+			port:     cfg["smtp_port"],
+			starttls: cfg["smtp_starttls"] != "false",
+			tls:      cfg["smtp_tls"] == "true",
+			username: cfg["smtp_username"],
+			password: cfg["smtp_password"],
+			from:     cfg["smtp_from"],
+			subject:  cfg["smtp_subject"],
+		}
+		if n.tls {
+			n.starttls = false
+		}
+		if n.port == "" {
+			n.port = "587"
+			if n.tls {
+				n.port = "465"
+			}
+		}
+		if n.subject == "" {
+			n.subject = "DNSControl"
+		}
+		for _, to := range strings.Split(cfg["smtp_to"], ",") {
+			if to = strings.TrimSpace(to); to != "" {
+				n.to = append(n.to, to)
+			}
+		}
+		if n.from == "" || len(n.to) == 0 {
+			return nil, errors.Errorf("smtp notifications need smtp_from and smtp_to")
+		}
+		if n.username != "" && !n.starttls && !n.tls {
+			return nil, errors.Errorf("smtp notifications with smtp_username need smtp_starttls or smtp_tls, so that the password is not sent unencrypted")
+		}
+		return n, nil
+	})
+}
+
+// smtpNotifier sends one summary email per run.
+type smtpNotifier struct {
+	host, port         string
+	starttls           bool
+	tls                bool // implicit TLS, usually on port 465
+	username, password string
+	from               string
+	to                 []string
+	subject            string
+
+	digest
+}
+
+func (s *smtpNotifier) Notify(domain, provider, msg string, err error, preview bool) {
+	s.add(domain, provider, msg, err, preview)
+}
+
+func (s *smtpNotifier) Done() {
+	if s.empty() {
+		return
+	}
+	defer s.reset()
+	msg, err := s.message()
+	if err != nil {
+		printer.Warnf("smtp notification: %s\n", err)
+		return
+	}
+	if err := s.send(msg); err != nil {
+		printer.Warnf("smtp notification failed: %s\n", err)
+	}
+}
+
+// send delivers msg to all recipients. If starttls is set, the server must support it.
+func (s *smtpNotifier) send(msg []byte) error {
+	c, err := s.dial()
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+	if s.starttls {
+		if ok, _ := c.Extension("STARTTLS"); !ok {
+			return errors.Errorf("%s does not support STARTTLS; set smtp_starttls to false to send unencrypted", s.host)
+		}
+		if err := c.StartTLS(&tls.Config{ServerName: s.host}); err != nil {
+			return err
+		}
+	}
+	if s.username != "" {
+		if err := c.Auth(smtp.PlainAuth("", s.username, s.password, s.host)); err != nil {
+			return err
+		}
+	}
+	if err := c.Mail(s.from); err != nil {
+		return err
+	}
+	for _, to := range s.to {
+		if err := c.Rcpt(to); err != nil {
+			return errors.Wrapf(err, "recipient %s", to)
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+// dial connects to the server, over TLS if tls is set.
+func (s *smtpNotifier) dial() (*smtp.Client, error) {
+	addr := net.JoinHostPort(s.host, s.port)
+	if !s.tls {
+		return smtp.Dial(addr)
+	}
+	conn, err := tls.Dial("tcp", addr, &tls.Config{ServerName: s.host})
+	if err != nil {
+		return nil, err
+	}
+	c, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// smtpSummary is the data given to the email templates.
+type smtpSummary struct {
+	Title   string
+	Preview bool
+	Errors  []smtpGroup
+	Changes []smtpGroup
+}
+
+type smtpGroup struct {
+	Domain, Provider string
+	Lines            []smtpLine
+}
+
+type smtpLine struct {
+	Message, Error string
+}
+
+func smtpGroups(groups []digestGroup) []smtpGroup {
+	out := []smtpGroup{}
+	for _, g := range groups {
+		sg := smtpGroup{Domain: g.domain, Provider: g.provider}
+		for _, r := range g.results {
+			l := smtpLine{Message: r.msg}
+			if r.err != nil {
+				l.Error = r.err.Error()
+			}
+			sg.Lines = append(sg.Lines, l)
+		}
+		out = append(out, sg)
+	}
+	return out
+}
+
+func (s *smtpNotifier) summary() *smtpSummary {
+	errs, oks := s.split()
+	sum := &smtpSummary{Preview: s.preview, Errors: smtpGroups(errs), Changes: smtpGroups(oks)}
+	if s.preview {
+		sum.Title = fmt.Sprintf("PREVIEW: %d changes pending, nothing was changed", countResults(oks))
+	} else {
+		sum.Title = fmt.Sprintf("push: %d changes made", countResults(oks))
+		if len(errs) > 0 {
+			sum.Title += fmt.Sprintf(", %d failed", countResults(errs))
+		}
+	}
+	return sum
+}
+
+var smtpTextTemplate = texttemplate.Must(texttemplate.New("text").Parse(`DNSControl {{.Title}}
+{{if .Errors}}
+ERRORS
+{{range .Errors}}
+{{.Domain}} [{{.Provider}}]
+{{range .Lines}}  - {{.Message}}
+    {{.Error}}
+{{end}}{{end}}{{end}}{{if .Changes}}
+{{if .Preview}}PENDING CHANGES{{else}}CHANGES MADE{{end}}
+{{range .Changes}}
+{{.Domain}} [{{.Provider}}]
+{{range .Lines}}  - {{.Message}}
+{{end}}{{end}}{{end}}`))
+
+var smtpHTMLTemplate = template.Must(template.New("html").Parse(`<html><body>
+<h2>DNSControl {{.Title}}</h2>
+{{if .Errors}}<h3>Errors</h3>
+{{range .Errors}}<h4>{{.Domain}} [{{.Provider}}]</h4>
+<ul>{{range .Lines}}<li><pre>{{.Message}}</pre><b>{{.Error}}</b></li>{{end}}</ul>
+{{end}}{{end}}{{if .Changes}}<h3>{{if .Preview}}Pending changes{{else}}Changes made{{end}}</h3>
+{{range .Changes}}<h4>{{.Domain}} [{{.Provider}}]</h4>
+<ul>{{range .Lines}}<li><pre>{{.Message}}</pre></li>{{end}}</ul>
+{{end}}{{end}}</body></html>
+`))
+
+// message builds a multipart/alternative email with plain text and HTML bodies.
+func (s *smtpNotifier) message() ([]byte, error) {
+	sum := s.summary()
+	var text, html bytes.Buffer
+	if err := smtpTextTemplate.Execute(&text, sum); err != nil {
+		return nil, err
+	}
+	if err := smtpHTMLTemplate.Execute(&html, sum); err != nil {
+		return nil, err
+	}
+	b := make([]byte, 12)
+	rand.Read(b)
+	boundary := "dnscontrol-" + hex.EncodeToString(b)
+	host, _ := os.Hostname()
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", s.from)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(s.to, ", "))
+	fmt.Fprintf(&msg, "Subject: %s: %s\r\n", s.subject, sum.Title)
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&msg, "Message-ID: <%s@%s>\r\n", hex.EncodeToString(b), host)
+	fmt.Fprintf(&msg, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&msg, "Content-Type: multipart/alternative; boundary=%q\r\n\r\n", boundary)
+	for _, part := range []struct {
+		contentType string
+		body        []byte
+	}{
+		{"text/plain", text.Bytes()},
+		{"text/html", html.Bytes()},
+	} {
+		fmt.Fprintf(&msg, "--%s\r\n", boundary)
+		fmt.Fprintf(&msg, "Content-Type: %s; charset=utf-8\r\n", part.contentType)
+		fmt.Fprintf(&msg, "Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+		qp := quotedprintable.NewWriter(&msg)
+		if _, err := qp.Write(part.body); err != nil {
+			return nil, err
+		}
+		qp.Close()
+		fmt.Fprintf(&msg, "\r\n")
+	}
+	fmt.Fprintf(&msg, "--%s--\r\n", boundary)
+	return msg.Bytes(), nil
+}
@@ -0,0 +1,202 @@
+package notifications
+
+import (
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"testing"
+)
+
+// fakeSMTP is a minimal SMTP server that records the messages it receives.
+type fakeSMTP struct {
+	ln       net.Listener
+	starttls bool
+	rcpts    []string
+	messages chan string
+}
+
+func newFakeSMTP(t *testing.T, starttls bool) *fakeSMTP {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &fakeSMTP{ln: ln, starttls: starttls, messages: make(chan string, 1)}
+	go s.serve()
+	return s
+}
+
+func (s *fakeSMTP) addr() (string, string) {
+	host, port, _ := net.SplitHostPort(s.ln.Addr().String())
+	return host, port
+}
+
+func (s *fakeSMTP) serve() {
+	conn, err := s.ln.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	c := textproto.NewConn(conn)
+	c.PrintfLine("220 localhost ready")
+	for {
+		line, err := c.ReadLine()
+		if err != nil {
+			return
+		}
+		cmd := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+		switch cmd {
+		case "EHLO":
+			if s.starttls {
+				c.PrintfLine("250-localhost")
+				c.PrintfLine("250 STARTTLS")
+			} else {
+				c.PrintfLine("250 localhost")
+			}
+		case "MAIL":
+			c.PrintfLine("250 ok")
+		case "RCPT":
+			s.rcpts = append(s.rcpts, line)
+			c.PrintfLine("250 ok")
+		case "DATA":
+			c.PrintfLine("354 go ahead")
+			b, err := c.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			s.messages <- string(b)
+			c.PrintfLine("250 ok")
+		case "QUIT":
+			c.PrintfLine("221 bye")
+			return
+		default:
+			c.PrintfLine("502 not implemented")
+		}
+	}
+}
+
+func TestSMTPSummary(t *testing.T) {
+	srv := newFakeSMTP(t, false)
+	defer srv.ln.Close()
+	host, port := srv.addr()
+	n := mustInit(t, map[string]string{
+		"smtp_host":     host,
+		"smtp_port":     port,
+		"smtp_starttls": "false",
+		"smtp_from":     "dnscontrol@example.com",
+		"smtp_to":       "ops@example.com, netops@example.com",
+	})
+	n.Notify("example.com", "bind", "CREATE A www <1.2.3.4>", nil, false)
+	n.Notify("example.com", "bind", "DELETE A old", fmt.Errorf("denied"), false)
+	n.Done()
+
+	var raw string
+	select {
+	case raw = <-srv.messages:
+	default:
+		t.Fatal("no message received")
+	}
+	if len(srv.rcpts) != 2 {
+		t.Errorf("expected 2 recipients, got %v", srv.rcpts)
+	}
+	msg, err := mail.ReadMessage(strings.NewReader(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if subj := msg.Header.Get("Subject"); !strings.Contains(subj, "1 changes made, 1 failed") {
+		t.Errorf("unexpected subject %q", subj)
+	}
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/alternative" {
+		t.Fatalf("unexpected content type %q: %v", mediaType, err)
+	}
+	parts := map[string]string{}
+	r := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		p, err := r.NextPart()
+		if err != nil {
+			break
+		}
+		b, _ := ioutil.ReadAll(p)
+		ct, _, _ := mime.ParseMediaType(p.Header.Get("Content-Type"))
+		parts[ct] = string(b)
+	}
+	text := parts["text/plain"]
+	if !strings.Contains(text, "CREATE A www <1.2.3.4>") || !strings.Contains(text, "denied") {
+		t.Errorf("unexpected text body %q", text)
+	}
+	html := parts["text/html"]
+	if !strings.Contains(html, "CREATE A www &lt;1.2.3.4&gt;") || !strings.Contains(html, "<h3>Errors</h3>") {
+		t.Errorf("unexpected html body %q", html)
+	}
+}
+
+func TestSMTPRequiresStartTLS(t *testing.T) {
+	srv := newFakeSMTP(t, false)
+	defer srv.ln.Close()
+	host, port := srv.addr()
+	n := &smtpNotifier{host: host, port: port, starttls: true, from: "a@example.com", to: []string{"b@example.com"}}
+	if err := n.send([]byte("x")); err == nil || !strings.Contains(err.Error(), "STARTTLS") {
+		t.Fatalf("expected STARTTLS error, got %v", err)
+	}
+}
+
+func TestSMTPConfig(t *testing.T) {
+	if _, err := Init(map[string]string{"smtp_host": "localhost"}); err == nil || !strings.Contains(err.Error(), "smtp_from and smtp_to") {
+		t.Errorf("expected an error without smtp_from and smtp_to, got %v", err)
+	}
+	base := map[string]string{"smtp_host": "mail.example.com", "smtp_from": "a@example.com", "smtp_to": "b@example.com", "smtp_username": "user", "smtp_password": "pass"}
+	for _, tst := range []struct {
+		starttls, tls string
+		ok            bool
+		port          string
+	}{
+		{"", "", true, "587"},
+		{"false", "", false, ""},
+		{"false", "true", true, "465"},
+		{"", "true", true, "465"},
+	} {
+		cfg := map[string]string{"smtp_starttls": tst.starttls, "smtp_tls": tst.tls}
+		for k, v := range base {
+			cfg[k] = v
+		}
+		notifier, err := Init(cfg)
+		if !tst.ok {
+			if err == nil || !strings.Contains(err.Error(), "unencrypted") {
+				t.Errorf("starttls=%q tls=%q: expected an error when the password would be sent unencrypted, got %v", tst.starttls, tst.tls, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("starttls=%q tls=%q: %s", tst.starttls, tst.tls, err)
+			continue
+		}
+		ns := notifier.(multiNotifier)
+		if len(ns) != 1 {
+			t.Errorf("starttls=%q tls=%q: expected a notifier", tst.starttls, tst.tls)
+			continue
+		}
+		if n := ns[0].(*smtpNotifier); n.port != tst.port || (n.tls && n.starttls) {
+			t.Errorf("starttls=%q tls=%q: got port %s, starttls %v, tls %v", tst.starttls, tst.tls, n.port, n.starttls, n.tls)
+		}
+	}
+}
+
+func TestSMTPImplicitTLS(t *testing.T) {
+	srv := newFakeSMTP(t, false)
+	defer srv.ln.Close()
+	host, port := srv.addr()
+	n := &smtpNotifier{host: host, port: port, tls: true, username: "user", password: "pass", from: "a@example.com", to: []string{"b@example.com"}}
+	if err := n.send([]byte("x")); err == nil {
+		t.Fatal("expected an error connecting with TLS to a plain server")
+	}
+	select {
+	case <-srv.messages:
+		t.Fatal("message was sent without TLS")
+	default:
+	}
+}
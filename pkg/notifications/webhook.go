@@ -0,0 +1,224 @@
+package notifications
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/StackExchange/dnscontrol/pkg/printer"
+)
+
+func init() {
+	initers = append(initers, func(cfg map[string]string) (Notifier, error) {
+		if _, ok := cfg["webhook_url"]; ok {
+			n, err := newWebhookNotifier(cfg)
+			if err != nil {
+				return nil, fmt.Errorf("webhook notifier: %s", err)
+			}
+			return n, nil
+		}
+		return nil, nil
+	})
+}
+
+// Default templates. Both produce JSON. A single message is rendered with a webhookMessage,
+// a batch with a webhookBatch.
+const (
+	defaultWebhookTemplate = `{"domain":{{json .Domain}},"provider":{{json .Provider}},"message":{{json .Message}},"error":{{json .Error}},"preview":{{.Preview}}}`
+
+	defaultWebhookBatchTemplate = `{"messages":[{{range $i, $m := .Messages}}{{if $i}},{{end}}` +
+		`{"domain":{{json $m.Domain}},"provider":{{json $m.Provider}},"message":{{json $m.Message}},"error":{{json $m.Error}},"preview":{{$m.Preview}}}` +
+		`{{end}}]}`
+)
+
+// webhookMessage is the data available to the body template for each notification.
+type webhookMessage struct {
+	Domain   string
+	Provider string
+	Message  string
+	Error    string
+	Preview  bool
+}
+
+// webhookBatch is the data available to the body template when batching.
+type webhookBatch struct {
+	Messages []webhookMessage
+}
+
+var webhookFuncs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+}
+
+// webhookNotifier sends an HTTP request for each notification, or one per run if batching.
+//
+// Configuration keys:
+//
+//	webhook_url           required
+//	webhook_method        default POST
+//	webhook_content_type  default application/json
+//	webhook_header_NAME   adds the header NAME
+//	webhook_template      Go template for the body
+//	webhook_batch         "true" to send one request in Done()
+//	webhook_retries       retries after a failed request, default 3
+//	webhook_retry_delay   delay before the first retry, doubled after each one, default 1s
+type webhookNotifier struct {
+	url         string
+	method      string
+	contentType string
+	headers     http.Header
+	tmpl        *template.Template
+	batch       bool
+	retries     int
+	retryDelay  time.Duration
+	client      *http.Client
+
+	pending []webhookMessage
+}
+
+func newWebhookNotifier(cfg map[string]string) (*webhookNotifier, error) {
+	w := &webhookNotifier{
+		url:         cfg["webhook_url"],
+		method:      strings.ToUpper(cfg["webhook_method"]),
+		contentType: cfg["webhook_content_type"],
+		headers:     http.Header{},
+		batch:       cfg["webhook_batch"] == "true",
+		retries:     3,
+		retryDelay:  time.Second,
+		client:      &http.Client{Timeout: 30 * time.Second},
+	}
+	if u, err := url.Parse(w.url); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("webhook_url %q is not an http or https URL", w.url)
+	}
+	switch w.method {
+	case "":
+		w.method = http.MethodPost
+	case http.MethodPost, http.MethodPut, http.MethodPatch:
+	default:
+		return nil, fmt.Errorf("webhook_method %q is not POST, PUT or PATCH", cfg["webhook_method"])
+	}
+	if w.contentType == "" {
+		w.contentType = "application/json"
+	}
+	for k, v := range cfg {
+		if strings.HasPrefix(k, "webhook_header_") {
+			w.headers.Set(strings.TrimPrefix(k, "webhook_header_"), v)
+		}
+	}
+	if s, ok := cfg["webhook_retries"]; ok {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("webhook_retries %q is not a valid number", s)
+		}
+		w.retries = n
+	}
+	if s, ok := cfg["webhook_retry_delay"]; ok {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return nil, fmt.Errorf("webhook_retry_delay %q: %s", s, err)
+		}
+		w.retryDelay = d
+	}
+	text, ok := cfg["webhook_template"]
+	if !ok {
+		text = defaultWebhookTemplate
+		if w.batch {
+			text = defaultWebhookBatchTemplate
+		}
+	}
+	var err error
+	if w.tmpl, err = template.New("webhook").Funcs(webhookFuncs).Parse(text); err != nil {
+		return nil, fmt.Errorf("webhook_template: %s", err)
+	}
+	return w, nil
+}
+
+func (w *webhookNotifier) Notify(domain, provider, msg string, err error, preview bool) {
+	m := webhookMessage{Domain: domain, Provider: provider, Message: msg, Preview: preview}
+	if err != nil {
+		m.Error = err.Error()
+	}
+	if w.batch {
+		w.pending = append(w.pending, m)
+		return
+	}
+	w.send(m)
+}
+
+func (w *webhookNotifier) Done() {
+	if !w.batch || len(w.pending) == 0 {
+		return
+	}
+	w.send(webhookBatch{Messages: w.pending})
+	w.pending = nil
+}
+
+// send renders the template with data and delivers it, retrying on failure.
+// Failures are reported as warnings; notifications never fail a run.
+func (w *webhookNotifier) send(data interface{}) {
+	buf := &bytes.Buffer{}
+	if err := w.tmpl.Execute(buf, data); err != nil {
+		printer.Warnf("webhook notification: rendering template: %s\n", err)
+		return
+	}
+	req := &httpRequest{method: w.method, url: w.url, contentType: w.contentType, headers: w.headers, body: buf.Bytes()}
+	if err := req.send(w.client, w.retries, w.retryDelay); err != nil {
+		printer.Warnf("webhook notification failed after %d attempts: %s\n", w.retries+1, err)
+	}
+}
+
+// httpRequest is a request that notifiers can send with retries.
+type httpRequest struct {
+	method      string
+	url         string
+	contentType string
+	headers     http.Header
+	body        []byte
+}
+
+// send makes the request, retrying up to retries times if it fails or returns a non-2xx status.
+// The delay between attempts starts at delay and doubles after each retry.
+func (r *httpRequest) send(client *http.Client, retries int, delay time.Duration) error {
+	var err error
+	for attempt := 0; attempt <= retries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(delay)
+			delay *= 2
+		}
+		if err = r.do(client); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+func (r *httpRequest) do(client *http.Client) error {
+	req, err := http.NewRequest(r.method, r.url, bytes.NewReader(r.body))
+	if err != nil {
+		return err
+	}
+	for k, v := range r.headers {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", r.contentType)
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("%s %s: %s", r.method, r.url, resp.Status)
+	}
+	return nil
+}
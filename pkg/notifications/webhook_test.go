@@ -0,0 +1,118 @@
+package notifications
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type recordingServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	requests []*http.Request
+	bodies   []string
+	failures int // number of requests to fail before succeeding
+}
+
+func newRecordingServer() *recordingServer {
+	s := &recordingServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.requests = append(s.requests, r)
+		s.bodies = append(s.bodies, string(body))
+		if s.failures > 0 {
+			s.failures--
+			http.Error(w, "try again", http.StatusServiceUnavailable)
+		}
+	}))
+	return s
+}
+
+// mustInit creates the notifiers configured by cfg.
+func mustInit(t *testing.T, cfg map[string]string) Notifier {
+	n, err := Init(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+func TestWebhookEachMessage(t *testing.T) {
+	srv := newRecordingServer()
+	defer srv.Close()
+	n := mustInit(t, map[string]string{
+		"webhook_url":                  srv.URL,
+		"webhook_header_Authorization": "Bearer xyz",
+	})
+	n.Notify("example.com", "bind", "CREATE A www", nil, true)
+	n.Notify("example.com", "bind", "DELETE A old", fmt.Errorf("denied"), false)
+	n.Done()
+
+	if len(srv.bodies) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(srv.bodies))
+	}
+	if got := srv.requests[0].Header.Get("Authorization"); got != "Bearer xyz" {
+		t.Errorf("expected Authorization header, got %q", got)
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal([]byte(srv.bodies[1]), &m); err != nil {
+		t.Fatalf("body is not json: %s: %s", err, srv.bodies[1])
+	}
+	if m["domain"] != "example.com" || m["error"] != "denied" || m["preview"] != false {
+		t.Errorf("unexpected body: %s", srv.bodies[1])
+	}
+}
+
+func TestWebhookBatchTemplateAndRetry(t *testing.T) {
+	srv := newRecordingServer()
+	defer srv.Close()
+	srv.failures = 2
+	n := mustInit(t, map[string]string{
+		"webhook_url":         srv.URL,
+		"webhook_method":      "put",
+		"webhook_batch":       "true",
+		"webhook_retry_delay": "1ms",
+		"webhook_template":    `{{range .Messages}}{{.Domain}}: {{.Message}}{{if .Preview}} (preview){{end}};{{end}}`,
+	})
+	n.Notify("a.com", "bind", "one", nil, true)
+	n.Notify("b.com", "bind", "two", nil, true)
+	if len(srv.bodies) != 0 {
+		t.Fatalf("expected no requests before Done, got %d", len(srv.bodies))
+	}
+	n.Done()
+	if len(srv.bodies) != 3 {
+		t.Fatalf("expected 2 failures and 1 success, got %d requests", len(srv.bodies))
+	}
+	if srv.requests[2].Method != "PUT" {
+		t.Errorf("expected PUT, got %s", srv.requests[2].Method)
+	}
+	if want := "a.com: one (preview);b.com: two (preview);"; srv.bodies[2] != want {
+		t.Errorf("expected body %q, got %q", want, srv.bodies[2])
+	}
+}
+
+func TestWebhookConfigErrors(t *testing.T) {
+	for _, cfg := range []map[string]string{
+		{"webhook_url": ""},
+		{"webhook_url": "alerts.example.com/dnscontrol"},
+		{"webhook_url": "ftp://alerts.example.com/dnscontrol"},
+		{"webhook_url": "https://alerts.example.com/dnscontrol", "webhook_method": "DELETE"},
+		{"webhook_url": "https://alerts.example.com/dnscontrol", "webhook_retries": "many"},
+		{"webhook_url": "https://alerts.example.com/dnscontrol", "webhook_template": "{{"},
+	} {
+		if n, err := Init(cfg); err == nil {
+			t.Errorf("%v: expected a config error, got %v", cfg, n)
+		}
+	}
+	// A route with a broken notifier fails too, instead of running without it.
+	if _, err := InitRoutes(map[string]map[string]string{"notifications.oncall": {"webhook_url": "not a url"}}); err == nil || !strings.Contains(err.Error(), "notifications.oncall") {
+		t.Errorf("expected the route's config error, got %v", err)
+	}
+}
@@ -0,0 +1,20 @@
+// +build !windows
+
+package bind
+
+import (
+	"syscall"
+
+	"github.com/StackExchange/dnscontrol/providers"
+)
+
+// wOK is W_OK from unistd.h.
+const wOK = 2
+
+// dirWriteAccess reports whether zonefiles can be created in dir, without creating anything.
+func dirWriteAccess(dir string) providers.WriteAccess {
+	if err := syscall.Access(dir, wOK); err != nil {
+		return providers.WriteDenied
+	}
+	return providers.WriteGranted
+}
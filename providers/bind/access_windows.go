@@ -0,0 +1,9 @@
+package bind
+
+import "github.com/StackExchange/dnscontrol/providers"
+
+// dirWriteAccess reports whether zonefiles can be created in dir, without creating anything.
+// It is not implemented on windows.
+func dirWriteAccess(dir string) providers.WriteAccess {
+	return providers.WriteUnknown
+}
@@ -38,6 +38,7 @@ var features = providers.DocumentationNotes{
 	providers.CanUseSSHFP:            providers.Can(),
 	providers.CanUseTLSA:             providers.Can(),
 	providers.CanUseTXTMulti:         providers.Can(),
+	providers.CanUseViews:            providers.Can("Each view is written to its own zonefile."),
 	providers.CantUseNOPURGE:         providers.Cannot(),
 	providers.DocCreateDomains:       providers.Can("Driver just maintains list of zone files. It should automatically add missing ones."),
 	providers.DocDualHost:            providers.Can(),
@@ -191,6 +192,27 @@ func makeDefaultSOA(info SoaInfo, origin string) *models.RecordConfig {
 	return &soaRec
 }
 
+// CheckCredentials lists the zonefiles in the directory and checks the permissions of the directory.
+// It does not change anything.
+func (c *Bind) CheckCredentials() (*providers.CredentialsReport, error) {
+	info, err := os.Stat(c.directory)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, errors.Errorf("%s is not a directory", c.directory)
+	}
+	files, err := filepath.Glob(filepath.Join(c.directory, "*.zone"))
+	if err != nil {
+		return nil, err
+	}
+	report := &providers.CredentialsReport{Account: c.directory, Zones: []string{}, Write: dirWriteAccess(c.directory)}
+	for _, f := range files {
+		report.Zones = append(report.Zones, strings.TrimSuffix(filepath.Base(f), ".zone"))
+	}
+	return report, nil
+}
+
 // GetNameservers returns the nameservers for a domain.
 func (c *Bind) GetNameservers(string) ([]*models.Nameserver, error) {
 	return c.nameservers, nil
@@ -223,7 +245,8 @@ func (c *Bind) GetDomainCorrections(dc *models.DomainConfig) ([]*models.Correcti
 		fmt.Printf("\nWARNING: BIND directory %q does not exist!\n", c.directory)
 	}
 
-	zonefile := filepath.Join(c.directory, strings.Replace(strings.ToLower(dc.Name), "/", "_", -1)+".zone")
+	// Views of the same zone are kept in separate files (example.com!view.zone).
+	zonefile := filepath.Join(c.directory, strings.Replace(strings.ToLower(dc.UniqueName()), "/", "_", -1)+".zone")
 	foundFH, err := os.Open(zonefile)
 	zoneFileFound := err == nil
 	if err != nil && !os.IsNotExist(os.ErrNotExist) {
@@ -284,16 +307,20 @@ func (c *Bind) GetDomainCorrections(dc *models.DomainConfig) ([]*models.Correcti
 			fmt.Fprintln(buf, i)
 		}
 	}
-	msg := fmt.Sprintf("GENERATE_ZONEFILE: %s\n", dc.Name)
+	msg := fmt.Sprintf("GENERATE_ZONEFILE: %s\n", dc.UniqueName())
 	if !zoneFileFound {
 		msg = msg + fmt.Sprintf(" (%d records)\n", len(create))
 	}
 	msg += buf.String()
 	corrections := []*models.Correction{}
 	if changes {
+		changed := append(append(append(diff.Changeset{}, create...), del...), mod...)
+		before, after := changed.Records()
 		corrections = append(corrections,
 			&models.Correction{
-				Msg: msg,
+				Msg:    msg,
+				Before: before,
+				After:  after,
 				F: func() error {
 					fmt.Printf("CREATING ZONEFILE: %v\n", zonefile)
 					zf, err := os.Create(zonefile)
@@ -0,0 +1,51 @@
+package bind
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/StackExchange/dnscontrol/providers"
+)
+
+func TestCheckCredentialsIsReadOnly(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dnscontrol-bind")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if err := ioutil.WriteFile(filepath.Join(dir, "example.com.zone"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	before, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := &Bind{directory: dir}
+	report, err := c.CheckCredentials()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Zones) != 1 || report.Zones[0] != "example.com" {
+		t.Errorf("expected zone example.com, got %v", report.Zones)
+	}
+	if runtime.GOOS != "windows" && report.Write != providers.WriteGranted {
+		t.Errorf("expected write access to the directory, got %s", report.Write)
+	}
+
+	after, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(after) != len(before) || after[0].ModTime() != before[0].ModTime() {
+		t.Errorf("check changed the directory: %v", after)
+	}
+
+	c.directory = filepath.Join(dir, "missing")
+	if _, err := c.CheckCredentials(); err == nil {
+		t.Error("expected an error for a missing directory")
+	}
+}
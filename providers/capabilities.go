@@ -49,6 +49,9 @@ const (
 
 	// CanUseRoute53Alias indicates the provider support the specific R53_ALIAS records that only the Route53 provider supports
 	CanUseRoute53Alias
+
+	// CanUseViews indicates the provider keeps each view of a zone apart, so several views of a zone may share it
+	CanUseViews
 )
 
 var providerCapabilities = map[string]map[Capability]bool{}
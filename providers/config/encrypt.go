@@ -0,0 +1,200 @@
+package config
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// Credentials can be encrypted with a passphrase, either as a whole file or one value at a time.
+// The passphrase is read from $DNSCONTROL_CREDS_KEY, or from the file named by $DNSCONTROL_CREDS_KEY_FILE.
+//
+// An encrypted file is EncryptedFileHeader followed by a base64 blob. An encrypted value is
+// EncryptedValuePrefix followed by a base64 blob. A blob is a random 16 byte salt, a random 12 byte
+// nonce and the AES-256-GCM ciphertext and tag. The 32 byte key is derived from the passphrase and
+// salt with PBKDF2-HMAC-SHA256 and 100000 iterations. The header or prefix, which includes the format
+// version, is authenticated as additional data, so a blob only decrypts where it was written.
+const (
+	EncryptedFileHeader  = "dnscontrol-encrypted:v1\n"
+	EncryptedValuePrefix = "enc:v1:"
+
+	saltSize   = 16
+	keySize    = 32
+	iterations = 100000
+)
+
+// IsEncryptedFile reports whether data is an encrypted credentials file.
+func IsEncryptedFile(data []byte) bool {
+	return bytes.HasPrefix(data, []byte(EncryptedFileHeader))
+}
+
+// IsEncryptedValue reports whether v is an encrypted credentials value.
+func IsEncryptedValue(v string) bool {
+	return strings.HasPrefix(v, EncryptedValuePrefix)
+}
+
+// KeyFromEnv returns the passphrase used to encrypt and decrypt credentials.
+func KeyFromEnv() (string, error) {
+	if key := os.Getenv("DNSCONTROL_CREDS_KEY"); key != "" {
+		return key, nil
+	}
+	if fname := os.Getenv("DNSCONTROL_CREDS_KEY_FILE"); fname != "" {
+		dat, err := ioutil.ReadFile(fname)
+		if err != nil {
+			return "", errors.Wrap(err, "reading DNSCONTROL_CREDS_KEY_FILE")
+		}
+		if key := strings.TrimSpace(string(dat)); key != "" {
+			return key, nil
+		}
+		return "", errors.Errorf("key file %s is empty", fname)
+	}
+	return "", errors.Errorf("credentials are encrypted: set DNSCONTROL_CREDS_KEY or DNSCONTROL_CREDS_KEY_FILE")
+}
+
+// Crypter encrypts and decrypts credentials with one passphrase. Deriving a key is deliberately
+// slow, so a Crypter uses one salt for everything it encrypts and remembers the keys it derives.
+type Crypter struct {
+	passphrase string
+	salt       []byte
+	keys       map[string]cipher.AEAD
+}
+
+// NewCrypter returns a Crypter for passphrase.
+func NewCrypter(passphrase string) (*Crypter, error) {
+	if passphrase == "" {
+		return nil, errors.Errorf("empty passphrase")
+	}
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	return &Crypter{passphrase: passphrase, salt: salt, keys: map[string]cipher.AEAD{}}, nil
+}
+
+func (c *Crypter) aead(salt []byte) (cipher.AEAD, error) {
+	if a, ok := c.keys[string(salt)]; ok {
+		return a, nil
+	}
+	block, err := aes.NewCipher(pbkdf2.Key([]byte(c.passphrase), salt, iterations, keySize, sha256.New))
+	if err != nil {
+		return nil, err
+	}
+	a, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	c.keys[string(salt)] = a
+	return a, nil
+}
+
+// seal encrypts plaintext into a base64 blob. header is the header or prefix the blob is written after.
+func (c *Crypter) seal(header string, plaintext []byte) (string, error) {
+	a, err := c.aead(c.salt)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, a.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	blob := append(append([]byte{}, c.salt...), nonce...)
+	blob = a.Seal(blob, nonce, plaintext, []byte(header))
+	return base64.StdEncoding.EncodeToString(blob), nil
+}
+
+// open decrypts a blob written by seal with the same header.
+func (c *Crypter) open(header, encoded string) ([]byte, error) {
+	blob, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, errors.Wrap(err, "decoding encrypted data")
+	}
+	if len(blob) < saltSize {
+		return nil, errors.Errorf("encrypted data is truncated")
+	}
+	a, err := c.aead(blob[:saltSize])
+	if err != nil {
+		return nil, err
+	}
+	blob = blob[saltSize:]
+	if len(blob) < a.NonceSize() {
+		return nil, errors.Errorf("encrypted data is truncated")
+	}
+	plaintext, err := a.Open(nil, blob[:a.NonceSize()], blob[a.NonceSize():], []byte(header))
+	if err != nil {
+		return nil, errors.Errorf("decryption failed: wrong key or corrupted data")
+	}
+	return plaintext, nil
+}
+
+// EncryptFile encrypts the contents of a credentials file.
+func (c *Crypter) EncryptFile(plaintext []byte) ([]byte, error) {
+	s, err := c.seal(EncryptedFileHeader, plaintext)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(EncryptedFileHeader + s + "\n"), nil
+}
+
+// DecryptFile decrypts a file encrypted with EncryptFile.
+func (c *Crypter) DecryptFile(data []byte) ([]byte, error) {
+	if !IsEncryptedFile(data) {
+		return nil, errors.Errorf("not an encrypted credentials file")
+	}
+	return c.open(EncryptedFileHeader, string(data[len(EncryptedFileHeader):]))
+}
+
+// EncryptValue encrypts a single credentials value.
+func (c *Crypter) EncryptValue(v string) (string, error) {
+	s, err := c.seal(EncryptedValuePrefix, []byte(v))
+	if err != nil {
+		return "", err
+	}
+	return EncryptedValuePrefix + s, nil
+}
+
+// DecryptValue decrypts a value encrypted with EncryptValue.
+func (c *Crypter) DecryptValue(v string) (string, error) {
+	if !IsEncryptedValue(v) {
+		return "", errors.Errorf("not an encrypted value")
+	}
+	plaintext, err := c.open(EncryptedValuePrefix, v[len(EncryptedValuePrefix):])
+	return string(plaintext), err
+}
+
+// decryptValues replaces every encrypted value in creds with its plaintext. The passphrase is
+// only looked up if there is something to decrypt.
+func decryptValues(creds *Creds, c *Crypter) error {
+	var err error
+	creds.Each(func(section string, vals map[string]string) {
+		for k, v := range vals {
+			if err != nil || !IsEncryptedValue(v) {
+				continue
+			}
+			if c == nil {
+				var key string
+				if key, err = KeyFromEnv(); err != nil {
+					return
+				}
+				if c, err = NewCrypter(key); err != nil {
+					return
+				}
+			}
+			var plaintext string
+			if plaintext, err = c.DecryptValue(v); err != nil {
+				err = errors.Wrapf(err, "%s.%s", section, k)
+				return
+			}
+			vals[k] = plaintext
+		}
+	})
+	return err
+}
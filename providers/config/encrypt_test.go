@@ -0,0 +1,95 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestEncryptValueRoundTrip(t *testing.T) {
+	c, err := NewCrypter("hunter2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	enc, err := c.EncryptValue("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsEncryptedValue(enc) {
+		t.Fatalf("expected encrypted value, got %q", enc)
+	}
+	// A fresh Crypter has to derive the key from the stored salt.
+	c2, _ := NewCrypter("hunter2")
+	if got, err := c2.DecryptValue(enc); err != nil || got != "secret" {
+		t.Fatalf("expected secret, got %q, %v", got, err)
+	}
+	wrong, _ := NewCrypter("wrong")
+	if _, err := wrong.DecryptValue(enc); err == nil {
+		t.Fatal("expected error decrypting with the wrong passphrase")
+	}
+}
+
+func TestTamperedHeader(t *testing.T) {
+	c, err := NewCrypter("hunter2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	file, err := c.EncryptFile([]byte(`{"r53": {"KeyId": "abc"}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	blob := strings.TrimPrefix(string(file), EncryptedFileHeader)
+
+	// The same blob under another header: a value prefix, or a future version of the format.
+	if _, err := c.DecryptValue(EncryptedValuePrefix + blob); err == nil {
+		t.Error("expected error decrypting a file's blob as a value")
+	}
+	if _, err := c.open("dnscontrol-encrypted:v2\n", blob); err == nil {
+		t.Error("expected error decrypting with a different header")
+	}
+	value, err := c.EncryptValue("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.DecryptFile([]byte(EncryptedFileHeader + strings.TrimPrefix(value, EncryptedValuePrefix))); err == nil {
+		t.Error("expected error decrypting a value's blob as a file")
+	}
+	if got, err := c.DecryptFile(file); err != nil || got == nil {
+		t.Errorf("expected the untouched file to decrypt, got %v", err)
+	}
+}
+
+func TestLoadEncryptedFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "creds")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	os.Setenv("DNSCONTROL_CREDS_KEY", "hunter2")
+	defer os.Unsetenv("DNSCONTROL_CREDS_KEY")
+
+	c, _ := NewCrypter("hunter2")
+	token, _ := c.EncryptValue("value-secret")
+	file, _ := c.EncryptFile([]byte(`{
+  // comments survive whole-file encryption
+  "r53": {"KeyId": "abc", "SecretKey": "` + token + `"},
+}`))
+	fname := filepath.Join(dir, "creds.json")
+	if err := ioutil.WriteFile(fname, file, 0600); err != nil {
+		t.Fatal(err)
+	}
+	cfgs, err := LoadProviderConfigs(fname, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfgs["r53"]["KeyId"] != "abc" || cfgs["r53"]["SecretKey"] != "value-secret" {
+		t.Fatalf("unexpected configs %v", cfgs)
+	}
+
+	os.Unsetenv("DNSCONTROL_CREDS_KEY")
+	if _, err := LoadProviderConfigs(fname, ""); err == nil {
+		t.Fatal("expected error without a key")
+	}
+}
@@ -2,12 +2,17 @@
 // It cleans nonstandard json features (comments and trailing commas), as well as replaces environment variable placeholders with
 // their environment variable equivalents. To reference an environment variable in your json file, simply use values in this format:
 //    "key"="$ENV_VAR_NAME"
+// Other kinds of references are described on resolver.
+// The file, or individual values in it, may be encrypted (see Crypter).
+// Several files can be layered, and named profiles can override their sections (see ReadProviderConfigs).
 package config
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/DisposaBoy/JsonConfigReader"
@@ -15,39 +20,180 @@ import (
 	"github.com/pkg/errors"
 )
 
-// LoadProviderConfigs will open the specified file name, and parse its contents. It will replace environment variables it finds if any value matches $[A-Za-z_-0-9]+
-func LoadProviderConfigs(fname string) (map[string]map[string]string, error) {
+// LoadProviderConfigs will open the specified file name, and parse its contents. It will replace references to
+// environment variables, files, commands and Vault secrets with their values (see resolver).
+// fname and profile are as for ReadProviderConfigs.
+func LoadProviderConfigs(fname string, profile string) (map[string]map[string]string, error) {
+	results, err := ReadProviderConfigs(fname, profile)
+	if err != nil {
+		return nil, err
+	}
+	sections := make([]string, 0, len(results))
+	for name := range results {
+		sections = append(sections, name)
+	}
+	if err = ResolveReferences(results, sections...); err != nil {
+		return nil, errors.Errorf("While reading provider credentials file %v: %v", fname, err)
+	}
+	return results, nil
+}
+
+// ReadProviderConfigs opens, decrypts and parses the specified files, without replacing any references.
+// Use ResolveReferences for the sections that will be used.
+//
+// fname may list several files separated by commas. They are merged in order: a key in a later file
+// replaces the same key of the same section in an earlier one. If profile is not empty, the sections of
+// that profile (from every file) are then merged in the same way. It is an error if no file defines the profile.
+func ReadProviderConfigs(fname string, profile string) (map[string]map[string]string, error) {
 	var results = map[string]map[string]string{}
+	var profiles []map[string]map[string]string
+	found := profile == ""
+	for _, name := range strings.Split(fname, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		creds, err := readCredsFile(name)
+		if err != nil {
+			return nil, err
+		}
+		if creds == nil {
+			continue
+		}
+		merge(results, creds.Sections)
+		if p, ok := creds.Profiles[profile]; ok && profile != "" {
+			profiles = append(profiles, p)
+			found = true
+		}
+	}
+	if !found {
+		return nil, errors.Errorf("profile %q is not defined in %s", profile, fname)
+	}
+	for _, p := range profiles {
+		merge(results, p)
+	}
+	return results, nil
+}
+
+// merge copies every key of every section of src into dst.
+func merge(dst, src map[string]map[string]string) {
+	for name, vals := range src {
+		if dst[name] == nil {
+			dst[name] = map[string]string{}
+		}
+		for k, v := range vals {
+			dst[name][k] = v
+		}
+	}
+}
+
+// readCredsFile reads and decrypts one credentials file. It returns nil if the file does not exist.
+func readCredsFile(fname string) (*Creds, error) {
 	dat, err := utfutil.ReadFile(fname, utfutil.POSIX)
 	if err != nil {
 		// no creds file is ok. Bind requires nothing for example. Individual providers will error if things not found.
 		if os.IsNotExist(err) {
 			fmt.Printf("INFO: Config file %q does not exist. Skipping.\n", fname)
-			return results, nil
+			return nil, nil
 		}
 		return nil, errors.Errorf("While reading provider credentials file %v: %v", fname, err)
 	}
-	s := string(dat)
-	r := JsonConfigReader.New(strings.NewReader(s))
-	err = json.NewDecoder(r).Decode(&results)
+	var crypter *Crypter
+	if IsEncryptedFile(dat) {
+		key, err := KeyFromEnv()
+		if err != nil {
+			return nil, errors.Errorf("While reading provider credentials file %v: %v", fname, err)
+		}
+		if crypter, err = NewCrypter(key); err != nil {
+			return nil, err
+		}
+		if dat, err = crypter.DecryptFile(dat); err != nil {
+			return nil, errors.Errorf("While decrypting provider credentials file %v: %v", fname, err)
+		}
+	}
+	creds, err := ParseCreds(dat)
 	if err != nil {
 		return nil, errors.Errorf("While parsing provider credentials file %v: %v", fname, err)
 	}
-	if err = replaceEnvVars(results); err != nil {
+	if err = decryptValues(creds, crypter); err != nil {
+		return nil, errors.Errorf("While decrypting provider credentials file %v: %v", fname, err)
+	}
+	// Make @file: references relative to this file, as they are resolved after merging.
+	dir, err := filepath.Abs(filepath.Dir(fname))
+	if err != nil {
 		return nil, err
 	}
-	return results, nil
+	creds.Each(func(section string, vals map[string]string) {
+		if !References(vals) {
+			return
+		}
+		for k, v := range vals {
+			if strings.HasPrefix(v, "@file:") && !filepath.IsAbs(v[len("@file:"):]) {
+				vals[k] = "@file:" + filepath.Join(dir, v[len("@file:"):])
+			}
+		}
+	})
+	return creds, nil
 }
 
-func replaceEnvVars(m map[string]map[string]string) error {
-	for _, keys := range m {
-		for k, v := range keys {
-			if strings.HasPrefix(v, "$") {
-				env := v[1:]
-				newVal := os.Getenv(env)
-				keys[k] = newVal
-			}
+// ResolveReferences replaces the references in the named sections of configs.
+// Each section is only resolved once, however often it is named. Unresolvable references are an error.
+func ResolveReferences(configs map[string]map[string]string, sections ...string) error {
+	return resolveReferences(configs, sections)
+}
+
+// Creds is the contents of one credentials file: a section of keys and values for each provider
+// (or for notifications), and optionally a "profiles" section with named sets of sections that
+// override them.
+type Creds struct {
+	Sections map[string]map[string]string
+	Profiles map[string]map[string]map[string]string
+}
+
+// ParseCreds parses the contents of a credentials file without decrypting or replacing anything.
+func ParseCreds(dat []byte) (*Creds, error) {
+	var raw map[string]json.RawMessage
+	r := JsonConfigReader.New(bytes.NewReader(dat))
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, err
+	}
+	creds := &Creds{Sections: map[string]map[string]string{}}
+	for name, msg := range raw {
+		var err error
+		if name == "profiles" {
+			err = json.Unmarshal(msg, &creds.Profiles)
+		} else {
+			vals := map[string]string{}
+			err = json.Unmarshal(msg, &vals)
+			creds.Sections[name] = vals
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "section %s", name)
+		}
+	}
+	return creds, nil
+}
+
+// Each calls f for every section, including those of profiles.
+func (c *Creds) Each(f func(section string, vals map[string]string)) {
+	for name, vals := range c.Sections {
+		f(name, vals)
+	}
+	for profile, sections := range c.Profiles {
+		for name, vals := range sections {
+			f("profiles."+profile+"."+name, vals)
 		}
 	}
-	return nil
+}
+
+// MarshalJSON writes c in the format read by ParseCreds.
+func (c *Creds) MarshalJSON() ([]byte, error) {
+	m := map[string]interface{}{}
+	for name, vals := range c.Sections {
+		m[name] = vals
+	}
+	if len(c.Profiles) > 0 {
+		m["profiles"] = c.Profiles
+	}
+	return json.Marshal(m)
 }
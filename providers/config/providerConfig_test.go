@@ -0,0 +1,55 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLayeredFilesAndProfiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "creds")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	base := filepath.Join(dir, "base.json")
+	local := filepath.Join(dir, "local.json")
+	ioutil.WriteFile(base, []byte(`{
+  "r53": {"KeyId": "base-id", "SecretKey": "base-secret"},
+  "bind": {"directory": "zones"},
+  "profiles": {
+    "staging": {"r53": {"KeyId": "staging-id"}}
+  }
+}`), 0600)
+	ioutil.WriteFile(local, []byte(`{
+  "r53": {"SecretKey": "local-secret"},
+  "profiles": {
+    "staging": {"r53": {"SecretKey": "staging-secret"}, "extra": {"token": "@file:token", "_references": "true"}}
+  }
+}`), 0600)
+	ioutil.WriteFile(filepath.Join(dir, "token"), []byte("tok\n"), 0600)
+
+	cfgs, err := LoadProviderConfigs(base+","+local, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfgs["r53"]["KeyId"] != "base-id" || cfgs["r53"]["SecretKey"] != "local-secret" || cfgs["bind"]["directory"] != "zones" {
+		t.Errorf("unexpected merged configs %v", cfgs)
+	}
+	if _, ok := cfgs["profiles"]; ok {
+		t.Error("profiles should not be a section")
+	}
+
+	cfgs, err = LoadProviderConfigs(base+","+local, "staging")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfgs["r53"]["KeyId"] != "staging-id" || cfgs["r53"]["SecretKey"] != "staging-secret" || cfgs["extra"]["token"] != "tok" {
+		t.Errorf("unexpected staging configs %v", cfgs)
+	}
+
+	if _, err := LoadProviderConfigs(base, "production"); err == nil {
+		t.Error("expected error for an undefined profile")
+	}
+}
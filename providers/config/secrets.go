@@ -0,0 +1,212 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/vault/api"
+	"github.com/pkg/errors"
+)
+
+// A credentials value can refer to a secret stored elsewhere:
+//
+//	$VAR                 the whole value is the environment variable VAR
+//
+// Entries that set "_references": "true" can also use:
+//
+//	${VAR}, ${VAR:-def}  interpolated anywhere in the value; def is used if VAR is unset or empty
+//	@file:path           the contents of a file, relative to the credentials file, without trailing newlines
+//	!command             the output of a shell command, without trailing newlines
+//	vault:path#key       the field key of the Vault secret at path (configured with the usual VAULT_* variables)
+//
+// Any other value is used as is. To write a literal value that looks like a reference, double its
+// first character: $$VAR, !!command, @@file:path. In entries with references, $$ is a literal $
+// anywhere in the value. A reference that cannot be resolved is an error.
+
+// referencesKey enables the references other than $VAR in an entry.
+const referencesKey = "_references"
+
+var (
+	envReference  = regexp.MustCompile(`^\$[A-Za-z_][A-Za-z0-9_]*$`)
+	interpolation = regexp.MustCompile(`\$\$|\$\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*))?\}`)
+)
+
+type resolver struct {
+	vault *api.Logical
+}
+
+// References reports whether the entry vals may use references other than $VAR.
+func References(vals map[string]string) bool {
+	return vals[referencesKey] == "true"
+}
+
+// IsReference reports whether v, a value of the entry vals, refers to a secret stored elsewhere.
+func IsReference(vals map[string]string, v string) bool {
+	if envReference.MatchString(v) {
+		return true
+	}
+	if !References(vals) || strings.HasPrefix(v, "!!") || strings.HasPrefix(v, "@@") {
+		return false
+	}
+	for _, prefix := range []string{"@file:", "!", "vault:"} {
+		if strings.HasPrefix(v, prefix) {
+			return true
+		}
+	}
+	for _, m := range interpolation.FindAllString(v, -1) {
+		if m != "$$" {
+			return true
+		}
+	}
+	return false
+}
+
+// resolve returns the value of v. refs enables the references other than $VAR.
+func (r *resolver) resolve(v string, refs bool) (string, error) {
+	if envReference.MatchString(v) {
+		val, ok := os.LookupEnv(v[1:])
+		if !ok {
+			return "", errors.Errorf("environment variable %s is not set", v[1:])
+		}
+		return val, nil
+	}
+	if !refs {
+		if strings.HasPrefix(v, "$$") {
+			return v[1:], nil
+		}
+		return v, nil
+	}
+	switch {
+	case strings.HasPrefix(v, "!!"), strings.HasPrefix(v, "@@"):
+		return v[1:], nil
+	case strings.HasPrefix(v, "@file:"):
+		return r.file(v[len("@file:"):])
+	case strings.HasPrefix(v, "!"):
+		return r.command(v[1:])
+	case strings.HasPrefix(v, "vault:"):
+		return r.vaultSecret(v[len("vault:"):])
+	}
+	var err error
+	out := interpolation.ReplaceAllStringFunc(v, func(ref string) string {
+		if ref == "$$" {
+			return "$"
+		}
+		m := interpolation.FindStringSubmatch(ref)
+		if val := os.Getenv(m[1]); val != "" {
+			return val
+		}
+		if m[2] != "" {
+			return m[3]
+		}
+		if _, ok := os.LookupEnv(m[1]); !ok && err == nil {
+			err = errors.Errorf("environment variable %s is not set", m[1])
+		}
+		return ""
+	})
+	return out, err
+}
+
+func (r *resolver) file(name string) (string, error) {
+	dat, err := ioutil.ReadFile(name)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(dat), "\r\n"), nil
+}
+
+func (r *resolver) command(command string) (string, error) {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/C", command)
+	} else {
+		cmd = exec.Command("sh", "-c", command)
+	}
+	var stderr bytes.Buffer
+	cmd.Stdin = os.Stdin
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", errors.Errorf("running %q: %s: %s", command, err, msg)
+		}
+		return "", errors.Errorf("running %q: %s", command, err)
+	}
+	return strings.TrimRight(string(out), "\r\n"), nil
+}
+
+func (r *resolver) vaultSecret(ref string) (string, error) {
+	i := strings.LastIndex(ref, "#")
+	if i < 0 {
+		return "", errors.Errorf("vault reference %q must be vault:path#key", ref)
+	}
+	path, key := ref[:i], ref[i+1:]
+	if r.vault == nil {
+		client, err := api.NewClient(api.DefaultConfig())
+		if err != nil {
+			return "", err
+		}
+		r.vault = client.Logical()
+	}
+	secret, err := r.vault.Read(path)
+	if err != nil {
+		return "", errors.Wrapf(err, "reading vault secret %s", path)
+	}
+	if secret == nil {
+		return "", errors.Errorf("vault secret %s does not exist", path)
+	}
+	data := secret.Data
+	// Version 2 of the KV secrets engine nests the fields under "data".
+	if nested, ok := data["data"].(map[string]interface{}); ok {
+		if _, found := data[key]; !found {
+			data = nested
+		}
+	}
+	val, ok := data[key]
+	if !ok {
+		return "", errors.Errorf("vault secret %s has no field %s", path, key)
+	}
+	s, ok := val.(string)
+	if !ok {
+		return "", errors.Errorf("vault secret %s field %s is not a string", path, key)
+	}
+	return s, nil
+}
+
+// resolveReferences replaces the references in the given sections of m.
+func resolveReferences(m map[string]map[string]string, sections []string) error {
+	sections = append([]string(nil), sections...)
+	sort.Strings(sections)
+	r := &resolver{}
+	var msgs []string
+	for i, name := range sections {
+		if i > 0 && sections[i-1] == name {
+			continue
+		}
+		keys := m[name]
+		refs := References(keys)
+		names := make([]string, 0, len(keys))
+		for k := range keys {
+			names = append(names, k)
+		}
+		sort.Strings(names)
+		for _, k := range names {
+			val, err := r.resolve(keys[k], refs)
+			if err != nil {
+				msgs = append(msgs, fmt.Sprintf("%s.%s: %s", name, k, err))
+				continue
+			}
+			keys[k] = val
+		}
+	}
+	if len(msgs) > 0 {
+		return errors.Errorf("unresolved references:\n  %s", strings.Join(msgs, "\n  "))
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package config
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestResolve(t *testing.T) {
+	dir, err := ioutil.TempDir("", "creds")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if err := ioutil.WriteFile(filepath.Join(dir, "token"), []byte("from-file\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	os.Setenv("DNSCONTROL_TEST_USER", "alice")
+	defer os.Unsetenv("DNSCONTROL_TEST_USER")
+	os.Unsetenv("DNSCONTROL_TEST_UNSET")
+
+	r := &resolver{}
+	tests := []struct {
+		in, out string
+	}{
+		{"plain", "plain"},
+		{"$DNSCONTROL_TEST_USER", "alice"},
+		{"user=${DNSCONTROL_TEST_USER};", "user=alice;"},
+		{"${DNSCONTROL_TEST_UNSET:-fallback}", "fallback"},
+		{"${DNSCONTROL_TEST_USER:-fallback}", "alice"},
+		{"@file:" + filepath.Join(dir, "token"), "from-file"},
+		{"$$DNSCONTROL_TEST_USER", "$DNSCONTROL_TEST_USER"},
+		{"pa$$word ${DNSCONTROL_TEST_USER} $${DNSCONTROL_TEST_USER}", "pa$word alice ${DNSCONTROL_TEST_USER}"},
+		{"!!echo from-command", "!echo from-command"},
+		{"@@file:token", "@file:token"},
+		{"$", "$"},
+	}
+	if runtime.GOOS != "windows" {
+		tests = append(tests, struct{ in, out string }{"!echo from-command", "from-command"})
+	}
+	for _, tst := range tests {
+		got, err := r.resolve(tst.in, true)
+		if err != nil {
+			t.Errorf("resolve(%q): %s", tst.in, err)
+		} else if got != tst.out {
+			t.Errorf("resolve(%q) = %q, want %q", tst.in, got, tst.out)
+		}
+	}
+
+	for _, bad := range []string{"$DNSCONTROL_TEST_UNSET", "x${DNSCONTROL_TEST_UNSET}", "@file:missing", "!exit 3", "vault:secret/dns"} {
+		if _, err := r.resolve(bad, true); err == nil {
+			t.Errorf("resolve(%q): expected error", bad)
+		}
+	}
+}
+
+func TestResolveOnlyNamedSections(t *testing.T) {
+	os.Unsetenv("DNSCONTROL_TEST_UNSET")
+	cfgs := map[string]map[string]string{
+		"used":   {"user": "${DNSCONTROL_TEST_UNSET:-bob}", "_references": "true"},
+		"unused": {"key": "$DNSCONTROL_TEST_UNSET"},
+	}
+	if err := ResolveReferences(cfgs, "used", "used"); err != nil {
+		t.Fatal(err)
+	}
+	if cfgs["used"]["user"] != "bob" || cfgs["unused"]["key"] != "$DNSCONTROL_TEST_UNSET" {
+		t.Errorf("unexpected configs %v", cfgs)
+	}
+	err := ResolveReferences(cfgs, "unused")
+	if err == nil || !strings.Contains(err.Error(), "unused.key") {
+		t.Errorf("expected error naming unused.key, got %v", err)
+	}
+}
+
+func TestLiteralValuesLoadUnchanged(t *testing.T) {
+	os.Setenv("DNSCONTROL_TEST_USER", "alice")
+	defer os.Unsetenv("DNSCONTROL_TEST_USER")
+	dir, err := ioutil.TempDir("", "creds")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	literals := map[string]string{
+		"dollar":        "$",
+		"dollar-digit":  "$5ecret",
+		"inner-dollars": "pa$$word",
+		"braces":        "${DNSCONTROL_TEST_USER}",
+		"bang":          "!echo not run",
+		"file":          "@file:token",
+		"vault":         "vault:secret/dns#key",
+		"plain":         "plain",
+	}
+	creds := map[string]map[string]string{"provider": {"user": "$DNSCONTROL_TEST_USER", "escaped": "$$DNSCONTROL_TEST_USER"}}
+	for k, v := range literals {
+		creds["provider"][k] = v
+	}
+	dat, err := json.Marshal(creds)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fname := filepath.Join(dir, "creds.json")
+	if err := ioutil.WriteFile(fname, dat, 0600); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadProviderConfigs(fname, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for k, v := range literals {
+		if got := loaded["provider"][k]; got != v {
+			t.Errorf("%s: got %q, want the literal value %q", k, got, v)
+		}
+		if IsReference(creds["provider"], v) {
+			t.Errorf("%s: %q is not a reference without _references", k, v)
+		}
+	}
+	if got := loaded["provider"]["user"]; got != "alice" {
+		t.Errorf("$VAR: got %q, want %q", got, "alice")
+	}
+	if got := loaded["provider"]["escaped"]; got != "$DNSCONTROL_TEST_USER" {
+		t.Errorf("$$VAR: got %q, want %q", got, "$DNSCONTROL_TEST_USER")
+	}
+}
@@ -220,6 +220,28 @@ func (c Correlation) String() string {
 	return fmt.Sprintf("MODIFY %s %s: (%s) -> (%s)", c.Existing.Type, c.Existing.GetLabelFQDN(), c.d.content(c.Existing), c.d.content(c.Desired))
 }
 
+// Records returns the record this correlation replaces and the record it creates,
+// in the form used by models.Correction Before and After.
+func (c Correlation) Records() (before, after []*models.RecordConfig) {
+	if c.Existing != nil {
+		before = append(before, c.Existing)
+	}
+	if c.Desired != nil {
+		after = append(after, c.Desired)
+	}
+	return before, after
+}
+
+// Records returns all records the changeset replaces and creates.
+func (c Changeset) Records() (before, after []*models.RecordConfig) {
+	for _, cor := range c {
+		b, a := cor.Records()
+		before = append(before, b...)
+		after = append(after, a...)
+	}
+	return before, after
+}
+
 func sortedKeys(m map[string]*models.RecordConfig) []string {
 	s := []string{}
 	for v := range m {
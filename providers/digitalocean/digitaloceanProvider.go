@@ -87,6 +87,35 @@ func (api *DoApi) EnsureDomainExists(domain string) error {
 	return err
 }
 
+// CheckCredentials reports the account's email address and the domains it can see.
+func (api *DoApi) CheckCredentials() (*providers.CredentialsReport, error) {
+	ctx := context.Background()
+	account, _, err := api.client.Account.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	report := &providers.CredentialsReport{Account: account.Email, Zones: []string{}}
+	opt := &godo.ListOptions{}
+	for {
+		domains, resp, err := api.client.Domains.List(ctx, opt)
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range domains {
+			report.Zones = append(report.Zones, d.Name)
+		}
+		if resp.Links == nil || resp.Links.IsLastPage() {
+			break
+		}
+		page, err := resp.Links.CurrentPage()
+		if err != nil {
+			return nil, err
+		}
+		opt.Page = page + 1
+	}
+	return report, nil
+}
+
 // GetNameservers returns the nameservers for domain.
 func (api *DoApi) GetNameservers(domain string) ([]*models.Nameserver, error) {
 	return models.StringsToNameservers(defaultNameServerNames), nil
@@ -129,6 +158,7 @@ func (api *DoApi) GetDomainCorrections(dc *models.DomainConfig) ([]*models.Corre
 				return err
 			},
 		}
+		corr.Before, corr.After = m.Records()
 		corrections = append(corrections, corr)
 	}
 	for _, m := range create {
@@ -140,6 +170,7 @@ func (api *DoApi) GetDomainCorrections(dc *models.DomainConfig) ([]*models.Corre
 				return err
 			},
 		}
+		corr.Before, corr.After = m.Records()
 		corrections = append(corrections, corr)
 	}
 	for _, m := range modify {
@@ -152,6 +183,7 @@ func (api *DoApi) GetDomainCorrections(dc *models.DomainConfig) ([]*models.Corre
 				return err
 			},
 		}
+		corr.Before, corr.After = m.Records()
 		corrections = append(corrections, corr)
 	}
 
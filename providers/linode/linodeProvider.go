@@ -173,6 +173,7 @@ func (api *LinodeApi) GetDomainCorrections(dc *models.DomainConfig) ([]*models.C
 				return api.deleteRecord(domainID, id)
 			},
 		}
+		corr.Before, corr.After = m.Records()
 		corrections = append(corrections, corr)
 	}
 	for _, m := range create {
@@ -195,6 +196,7 @@ func (api *LinodeApi) GetDomainCorrections(dc *models.DomainConfig) ([]*models.C
 				return api.modifyRecord(domainID, record.ID, req)
 			},
 		}
+		corr.Before, corr.After = m.Records()
 		corrections = append(corrections, corr)
 	}
 	for _, m := range modify {
@@ -216,6 +218,7 @@ func (api *LinodeApi) GetDomainCorrections(dc *models.DomainConfig) ([]*models.C
 				return api.modifyRecord(domainID, id, req)
 			},
 		}
+		corr.Before, corr.After = m.Records()
 		corrections = append(corrections, corr)
 	}
 
@@ -8,41 +8,41 @@ import (
 	"github.com/StackExchange/dnscontrol/models"
 	"github.com/StackExchange/dnscontrol/pkg/transform"
 
-	"github.com/robertkrimen/otto"
-	// load underscore js into vm by default
-
-	_ "github.com/robertkrimen/otto/underscore" // required by otto
+	"github.com/dop251/goja"
+	"github.com/pkg/errors"
+	"github.com/robertkrimen/otto/underscore"
 )
 
 // ExecuteJavascript accepts a javascript string and runs it, returning the resulting dnsConfig.
 func ExecuteJavascript(script string, devMode bool) (*models.DNSConfig, error) {
-	vm := otto.New()
+	vm := goja.New()
+
+	vm.Set("require", require(vm))
+	vm.Set("REV", reverse(vm))
 
-	vm.Set("require", require)
-	vm.Set("REV", reverse)
+	// load underscore.js, which helpers.js depends on
+	if _, err := vm.RunScript("underscore.js", underscore.Source()); err != nil {
+		return nil, err
+	}
 
 	helperJs := GetHelpers(true)
 	// run helper script to prime vm and initialize variables
-	if _, err := vm.Run(helperJs); err != nil {
+	if _, err := vm.RunScript("helpers.js", helperJs); err != nil {
 		return nil, err
 	}
 
 	// run user script
-	if _, err := vm.Run(script); err != nil {
+	if _, err := vm.RunString(script); err != nil {
 		return nil, err
 	}
 
 	// export conf as string and unmarshal
-	value, err := vm.Run(`JSON.stringify(conf)`)
-	if err != nil {
-		return nil, err
-	}
-	str, err := value.ToString()
+	value, err := vm.RunString(`JSON.stringify(conf)`)
 	if err != nil {
 		return nil, err
 	}
 	conf := &models.DNSConfig{}
-	if err = json.Unmarshal([]byte(str), conf); err != nil {
+	if err = json.Unmarshal([]byte(value.String()), conf); err != nil {
 		return nil, err
 	}
 	return conf, nil
@@ -57,36 +57,43 @@ func GetHelpers(devMode bool) string {
 	return string(d)
 }
 
-func require(call otto.FunctionCall) otto.Value {
-	if len(call.ArgumentList) != 1 {
-		throw(call.Otto, "require takes exactly one argument")
+func require(vm *goja.Runtime) func(call goja.FunctionCall) goja.Value {
+	return func(call goja.FunctionCall) goja.Value {
+		if len(call.Arguments) != 1 {
+			throw(vm, "require takes exactly one argument")
+		}
+		file := call.Argument(0).String()
+		fmt.Printf("requiring: %s\n", file)
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			throw(vm, err.Error())
+		}
+		_, err = vm.RunScript(file, string(data))
+		if err != nil {
+			throw(vm, err.Error())
+		}
+		return vm.ToValue(true)
 	}
-	file := call.Argument(0).String()
-	fmt.Printf("requiring: %s\n", file)
-	data, err := ioutil.ReadFile(file)
-	if err != nil {
-		throw(call.Otto, err.Error())
-	}
-	_, err = call.Otto.Run(string(data))
-	if err != nil {
-		throw(call.Otto, err.Error())
-	}
-	return otto.TrueValue()
 }
 
-func throw(vm *otto.Otto, str string) {
-	panic(vm.MakeCustomError("Error", str))
+func throw(vm *goja.Runtime, str string) {
+	e, err := vm.New(vm.Get("Error"), vm.ToValue(str))
+	if err != nil {
+		panic(vm.NewGoError(errors.New(str)))
+	}
+	panic(e)
 }
 
-func reverse(call otto.FunctionCall) otto.Value {
-	if len(call.ArgumentList) != 1 {
-		throw(call.Otto, "REV takes exactly one argument")
-	}
-	dom := call.Argument(0).String()
-	rev, err := transform.ReverseDomainName(dom)
-	if err != nil {
-		throw(call.Otto, err.Error())
+func reverse(vm *goja.Runtime) func(call goja.FunctionCall) goja.Value {
+	return func(call goja.FunctionCall) goja.Value {
+		if len(call.Arguments) != 1 {
+			throw(vm, "REV takes exactly one argument")
+		}
+		dom := call.Argument(0).String()
+		rev, err := transform.ReverseDomainName(dom)
+		if err != nil {
+			throw(vm, err.Error())
+		}
+		return vm.ToValue(rev)
 	}
-	v, _ := otto.ToValue(rev)
-	return v
 }
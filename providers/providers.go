@@ -3,6 +3,7 @@ package providers
 import (
 	"encoding/json"
 	"log"
+	"sort"
 
 	"github.com/StackExchange/dnscontrol/models"
 	"github.com/pkg/errors"
@@ -24,6 +25,43 @@ type DomainCreator interface {
 	EnsureDomainExists(domain string) error
 }
 
+// CredentialsChecker should be implemented by providers that can check their credentials with read-only requests,
+// for example by listing zones or fetching account information. The check-creds command uses it to report what
+// the credentials allow before anything is pushed.
+type CredentialsChecker interface {
+	CheckCredentials() (*CredentialsReport, error)
+}
+
+// CredentialsReport describes what a provider's credentials allow.
+type CredentialsReport struct {
+	// Account identifies the account or user, if the API reports it.
+	Account string
+	// Zones lists the zones visible with the credentials, or is nil if the provider cannot list them.
+	Zones []string
+	// Write tells whether the credentials may change zones, if the API reports it.
+	Write WriteAccess
+}
+
+// WriteAccess is whether credentials may change zones.
+type WriteAccess int
+
+// Values of WriteAccess.
+const (
+	WriteUnknown WriteAccess = iota
+	WriteGranted
+	WriteDenied
+)
+
+func (w WriteAccess) String() string {
+	switch w {
+	case WriteGranted:
+		return "granted"
+	case WriteDenied:
+		return "denied"
+	}
+	return "unknown"
+}
+
 // RegistrarInitializer is a function to create a registrar. Function will be passed the unprocessed json payload from the configuration file for the given provider.
 type RegistrarInitializer func(map[string]string) (Registrar, error)
 
@@ -117,3 +155,13 @@ func GetCustomRecordType(rType string) *CustomRType {
 }
 
 var customRecordTypes = map[string]*CustomRType{}
+
+// CustomRecordTypes returns the names of all registered custom record types, sorted.
+func CustomRecordTypes() []string {
+	names := make([]string, 0, len(customRecordTypes))
+	for name := range customRecordTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
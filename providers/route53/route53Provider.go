@@ -15,6 +15,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws/session"
 	r53 "github.com/aws/aws-sdk-go/service/route53"
 	r53d "github.com/aws/aws-sdk-go/service/route53domains"
+	"github.com/aws/aws-sdk-go/service/sts"
 	"github.com/pkg/errors"
 )
 
@@ -23,6 +24,7 @@ type route53Provider struct {
 	registrar     *r53d.Route53Domains
 	delegationSet *string
 	zones         map[string]*r53.HostedZone
+	sts           *sts.STS
 }
 
 func newRoute53Reg(conf map[string]string) (providers.Registrar, error) {
@@ -54,7 +56,7 @@ func newRoute53(m map[string]string, metadata json.RawMessage) (*route53Provider
 		fmt.Printf("ROUTE53 DelegationSet %s configured\n", val)
 		dls = sPtr(val)
 	}
-	api := &route53Provider{client: r53.New(sess), registrar: r53d.New(sess), delegationSet: dls}
+	api := &route53Provider{client: r53.New(sess), registrar: r53d.New(sess), delegationSet: dls, sts: sts.New(sess)}
 	err := api.getZones()
 	if err != nil {
 		return nil, err
@@ -137,6 +139,26 @@ func (r *route53Provider) getZones() error {
 	return nil
 }
 
+// CheckCredentials reports the AWS identity and the hosted zones it can see.
+// Route 53 does not report whether the identity may change them.
+func (r *route53Provider) CheckCredentials() (*providers.CredentialsReport, error) {
+	report := &providers.CredentialsReport{Zones: []string{}}
+	// Any valid credentials may call GetCallerIdentity, so an error means they are not valid.
+	id, err := r.sts.GetCallerIdentity(&sts.GetCallerIdentityInput{})
+	if err != nil {
+		return nil, errors.Wrap(err, "getting the AWS identity")
+	}
+	report.Account = aws.StringValue(id.Arn)
+	if err := r.getZones(); err != nil {
+		return nil, err
+	}
+	for name := range r.zones {
+		report.Zones = append(report.Zones, name)
+	}
+	sort.Strings(report.Zones)
+	return report, nil
+}
+
 type errNoExist struct {
 	domain string
 }
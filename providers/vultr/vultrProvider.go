@@ -140,6 +140,31 @@ func (api *Provider) EnsureDomainExists(domain string) error {
 	return api.client.DNSDomain.Create(context.Background(), domain, "0.0.0.0")
 }
 
+// CheckCredentials reports the API key's user, the domains it can see, and whether its ACL allows managing DNS.
+func (api *Provider) CheckCredentials() (*providers.CredentialsReport, error) {
+	info, err := api.client.API.GetInfo(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	report := &providers.CredentialsReport{Account: info.Email, Zones: []string{}}
+	for _, acl := range info.ACL {
+		if acl == "dns" {
+			report.Write = providers.WriteGranted
+		}
+	}
+	if report.Write == providers.WriteUnknown && len(info.ACL) > 0 {
+		report.Write = providers.WriteDenied
+	}
+	domains, err := api.client.DNSDomain.List(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range domains {
+		report.Zones = append(report.Zones, d.Domain)
+	}
+	return report, nil
+}
+
 func (api *Provider) isDomainInAccount(domain string) (bool, error) {
 	domains, err := api.client.DNSDomain.List(context.Background())
 	if err != nil {